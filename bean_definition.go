@@ -9,12 +9,22 @@ import (
 type (
 	// bean定义
 	definition struct {
-		Name        string
-		Type        reflect.Type
-		awareMap    map[string]aware // fieldName:aware
-		valueMap    map[string]aware // fieldName:aware
-		factory     reflect.Value    // 工厂函数；非零值表示工厂模式，按入参类型注入
-		factoryArgs []reflect.Type   // 工厂入参类型列表
+		Name          string
+		Type          reflect.Type
+		awareMap      map[string]aware   // fieldName:aware
+		valueMap      map[string]aware   // fieldName:aware
+		factory       reflect.Value      // 工厂函数；非零值表示工厂模式，按入参类型注入
+		factoryArgs   []reflect.Type     // 工厂入参类型列表
+		scope         Scope              // 作用域，默认单例
+		lazyInit      bool               // 单例延迟到首次被查找时创建（WithLazyInit）
+		conditions    []condition        // 注册条件，Load 时评估
+		qualifiers    []string           // 限定标签（BeanQualifiers 接口 + Qualifier 选项）
+		order         int                // 排序值（Ordered 接口 / WithOrder 选项）
+		phase         int                // 生命周期阶段（Phased 接口 / WithPhase 选项）
+		dependsOn     []string           // 显式声明的依赖（BeanDependsOn 接口 + DependsOn 选项）
+		argQualifiers map[int][]string   // 工厂入参下标:要求的限定标签（ArgQualifier）
+		params        map[int]definition // 工厂入参下标:参数结构体（嵌入 di.In）的字段定义
+		injectFields  bool               // 对工厂产物做字段注入（WithFieldInjection）
 	}
 
 	// 需要注入的信息
@@ -28,9 +38,41 @@ type (
 		IsSlice     bool         // 是否为 slice，收集所有可赋值给 ElemType 的 bean
 		IsMap       bool         // 是否为 map[string]T，以 beanName 为 key 收集
 		ElemType    reflect.Type // slice/map 的元素类型
+		IsLazy      bool         // 是否为 Lazy[T] 字段，Type 为目标类型 T
+		Qualifiers  []string     // 要求的限定标签（qualifier=x），按类型查找并筛选
 	}
 )
 
+// instanceType 返回 bean 实例的类型：原型定义为 *Type，工厂定义为返回类型（指针或接口）。
+func (def definition) instanceType() reflect.Type {
+	if def.factory.IsValid() {
+		return def.Type
+	}
+	return reflect.PointerTo(def.Type)
+}
+
+// zeroInstance 返回实例类型的零值实例，用于读取类型声明的接口（BeanName、BeanScope 等）；
+// 实例类型不是指针（工厂返回接口类型）时返回 nil
+func zeroInstance(instanceType reflect.Type) any {
+	if instanceType.Kind() != reflect.Pointer {
+		return nil
+	}
+	return reflect.New(instanceType.Elem()).Interface()
+}
+
+// deferred 判断定义是否在 Load 时跳过、按需创建：原型作用域，或声明了 WithLazyInit 的单例。
+func (def definition) deferred() bool {
+	return def.scope == ScopePrototype || (def.scope == ScopeSingleton && def.lazyInit)
+}
+
+// declaredScope 读取实例类型实现的 BeanScope 接口声明的作用域，未实现时为单例。
+func declaredScope(instanceType reflect.Type) Scope {
+	if s, ok := zeroInstance(instanceType).(BeanScope); ok {
+		return s.Scope()
+	}
+	return ScopeSingleton
+}
+
 func (container *di) newDefinition(beanName string, prototype reflect.Type) definition {
 	def := definition{Name: beanName, Type: prototype}
 	awareMap := map[string]aware{}
@@ -39,24 +81,29 @@ func (container *di) newDefinition(beanName string, prototype reflect.Type) defi
 		field := prototype.Field(i)
 		switch field.Type.Kind() {
 		case reflect.Pointer, reflect.Interface, reflect.Struct:
-			if awareName, ok := field.Tag.Lookup("aware"); ok {
-				omitempty := false
-				switch {
-				case strings.EqualFold(awareName, "omitempty"):
-					omitempty = true
-					awareName = ""
-				case strings.HasSuffix(awareName, ",omitempty"):
-					omitempty = true
-					awareName = strings.TrimSuffix(awareName, ",omitempty")
+			if tag, ok := field.Tag.Lookup("aware"); ok {
+				awareName, omitempty, qualifiers, err := parseAwareTag(tag)
+				if err != nil {
+					panic(fmt.Errorf("%w: %s for %s.%s", ErrDefinition, err.Error(), prototype.String(), field.Name))
 				}
 
-				switch field.Type.Kind() {
+				// Lazy[T] 字段：按目标类型 T 解析依赖，注入时只绑定解析方式
+				fieldType, isLazy := field.Type, false
+				if field.Type.Kind() == reflect.Struct && reflect.PointerTo(field.Type).Implements(lazyBinderType) {
+					fieldType, isLazy = reflect.New(field.Type).Interface().(lazyBinder).lazyTarget(), true
+					if kind := fieldType.Kind(); kind != reflect.Pointer && kind != reflect.Interface {
+						panic(fmt.Errorf("%w: lazy target must be a pointer or interface for %s.%s", ErrDefinition, prototype.String(), field.Name))
+					}
+				}
+
+				switch fieldType.Kind() {
 				case reflect.Pointer:
-					if reflect.Interface == field.Type.Elem().Kind() {
+					if reflect.Interface == fieldType.Elem().Kind() {
 						panic(fmt.Errorf("%w: aware bean not accept interface pointer for %s.%s", ErrDefinition, prototype.String(), field.Name))
 					}
-					tmpBean := reflect.New(field.Type.Elem()).Interface()
-					if awareName == "" {
+					tmpBean := reflect.New(fieldType.Elem()).Interface()
+					// 声明了限定标签时按类型查找，不推断 beanName
+					if awareName == "" && len(qualifiers) == 0 {
 						switch tmpBean.(type) {
 						case BeanName: // 取接口返回值为注入的beanName
 							if name := tmpBean.(BeanName).BeanName(); name != "" {
@@ -64,12 +111,12 @@ func (container *di) newDefinition(beanName string, prototype reflect.Type) defi
 							}
 						}
 					}
-					if awareName == "" {
+					if awareName == "" && len(qualifiers) == 0 {
 						// 取类型名称为注入的beanName
-						awareName = GetBeanName(field.Type)
+						awareName = GetBeanName(fieldType)
 					}
 					// 检查匿名类
-					if field.Anonymous {
+					if field.Anonymous && !isLazy {
 						errInterface := checkAnonymousFieldBean(tmpBean)
 						if errInterface != "" {
 							container.log.Fatal(fmt.Errorf("%w: %s(%s) as anonymous field in %s(%s.%s) can not implements %s",
@@ -82,32 +129,41 @@ func (container *di) newDefinition(beanName string, prototype reflect.Type) defi
 
 					// 注册aware信息
 					awareMap[field.Name] = aware{
-						Name:      awareName,
-						Type:      field.Type,
-						IsPtr:     true,
-						Anonymous: field.Anonymous,
-						Omitempty: omitempty,
+						Name:       awareName,
+						Type:       fieldType,
+						IsPtr:      true,
+						Anonymous:  field.Anonymous && !isLazy,
+						Omitempty:  omitempty,
+						IsLazy:     isLazy,
+						Qualifiers: qualifiers,
 					}
 				case reflect.Interface:
 					// 取类型名称为注入的beanName
-					if awareName == "" {
-						awareName = GetBeanName(field.Type)
+					if awareName == "" && len(qualifiers) == 0 {
+						awareName = GetBeanName(fieldType)
 					}
 					// 注册aware信息
 					awareMap[field.Name] = aware{
 						Name:        awareName,
-						Type:        field.Type,
+						Type:        fieldType,
 						IsPtr:       false,
 						IsInterface: true,
-						Anonymous:   field.Anonymous,
+						Anonymous:   field.Anonymous && !isLazy,
 						Omitempty:   omitempty,
+						IsLazy:      isLazy,
+						Qualifiers:  qualifiers,
 					}
 				case reflect.Struct:
 					panic(fmt.Errorf("%w: aware bean not accept struct for %s.%s", ErrDefinition, prototype.String(), field.Name))
 				}
 			}
 		case reflect.Slice, reflect.Map:
-			if awareName, ok := field.Tag.Lookup("aware"); ok {
+			if tag, ok := field.Tag.Lookup("aware"); ok {
+				// slice/map 的 awareName 不影响行为，靠类型收集
+				_, omitempty, qualifiers, err := parseAwareTag(tag)
+				if err != nil {
+					panic(fmt.Errorf("%w: %s for %s.%s", ErrDefinition, err.Error(), prototype.String(), field.Name))
+				}
 				// 解析元素类型
 				elemType := field.Type.Elem()
 				// map 必须是 map[string]T
@@ -115,20 +171,13 @@ func (container *di) newDefinition(beanName string, prototype reflect.Type) defi
 				if isMap && field.Type.Key().Kind() != reflect.String {
 					panic(fmt.Errorf("%w: aware map key must be string for %s.%s", ErrDefinition, prototype.String(), field.Name))
 				}
-				// omitempty 解析（slice/map 的 awareName 不影响行为，靠类型收集）
-				omitempty := false
-				switch {
-				case strings.EqualFold(awareName, "omitempty"):
-					omitempty = true
-				case strings.HasSuffix(awareName, ",omitempty"):
-					omitempty = true
-				}
 				awareMap[field.Name] = aware{
-					Type:      field.Type,
-					ElemType:  elemType,
-					IsSlice:   !isMap,
-					IsMap:     isMap,
-					Omitempty: omitempty,
+					Type:       field.Type,
+					ElemType:   elemType,
+					IsSlice:    !isMap,
+					IsMap:      isMap,
+					Omitempty:  omitempty,
+					Qualifiers: qualifiers,
 				}
 			}
 		case reflect.String, reflect.Bool,
@@ -152,6 +201,30 @@ func (container *di) newDefinition(beanName string, prototype reflect.Type) defi
 	return def
 }
 
+// parseAwareTag 解析 aware 标签：`name,omitempty,qualifier=x`。
+// 单独的 "omitempty" 视为选项而非名称；qualifier 可出现多次，要求同时具备。
+func parseAwareTag(tag string) (name string, omitempty bool, qualifiers []string, err error) {
+	if strings.EqualFold(tag, "omitempty") {
+		return "", true, nil, nil
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	for _, option := range strings.Split(options, ",") {
+		option = strings.TrimSpace(option)
+		switch {
+		case option == "":
+		case strings.EqualFold(option, "omitempty"):
+			omitempty = true
+		case strings.HasPrefix(option, "qualifier="):
+			if qualifier := strings.TrimPrefix(option, "qualifier="); qualifier != "" {
+				qualifiers = append(qualifiers, qualifier)
+			}
+		default:
+			return "", false, nil, fmt.Errorf("unknown aware option %q", option)
+		}
+	}
+	return name, omitempty, qualifiers, nil
+}
+
 func (container *di) getValueDefinition(prototype reflect.Type) definition {
 	def := definition{Name: prototype.Name(), Type: prototype}
 	valueMap := map[string]aware{}
@@ -190,11 +263,13 @@ var anonymousForbiddenInterfaces = []reflect.Type{
 	reflect.TypeFor[InitializedWithContainer](),
 	reflect.TypeFor[Disposable](),
 	reflect.TypeFor[DisposableWithContainer](),
+	reflect.TypeFor[Startable](),
+	reflect.TypeFor[Stoppable](),
 }
 
 // checkAnonymousFieldBean 检查匿名字段不能实现的接口
 func checkAnonymousFieldBean(awareBean any) string {
-	// 匿名字段不能实现BeanConstruct/PreInitialize/AfterPropertiesSet/Initialized/Disposable等生命周期接口
+	// 匿名字段不能实现BeanConstruct/PreInitialize/AfterPropertiesSet/Initialized/Disposable/Startable/Stoppable等生命周期接口
 	for _, iface := range anonymousForbiddenInterfaces {
 		if reflect.TypeOf(awareBean).Implements(iface) {
 			return iface.Name()
@@ -8,10 +8,12 @@ import (
 
 // Dependency 描述 bean 的一个字段级依赖。
 type Dependency struct {
-	Field     string       // 字段名
-	Name      string       // 注入的 bean 名（aware）或配置项 key（value）；slice/map 注入按类型收集，为空
-	Type      reflect.Type // 字段类型
-	Omitempty bool         // 是否可选（omitempty 标签）
+	Field      string       // 字段名
+	Name       string       // 注入的 bean 名（aware）或配置项 key（value）；slice/map 与限定标签注入按类型收集，为空
+	Type       reflect.Type // 字段类型
+	Omitempty  bool         // 是否可选（omitempty 标签）
+	Lazy       bool         // 是否为 Lazy[T] 字段（Type 为目标类型 T）
+	Qualifiers []string     // 要求的限定标签（qualifier=x）
 }
 
 // BeanDescription 描述 bean 定义（只读快照，供管理/诊断）。
@@ -21,6 +23,12 @@ type BeanDescription struct {
 	Name         string       // bean 名称
 	Type         reflect.Type // 类型（原型为结构体类型，工厂 bean 为返回指针类型）
 	Factory      bool         // 是否为工厂模式
+	Scope        Scope        // 作用域
+	LazyInit     bool         // 是否延迟创建（WithLazyInit）
+	Module       string       // 注册该 bean 的模块名称（Install），非模块注册为空
+	Qualifiers   []string     // bean 具备的限定标签
+	DependsOn    []string     // 显式声明的依赖（BeanDependsOn / DependsOn）
+	Decorators   []string     // 作用于 bean 的装饰函数名称（按应用顺序）
 	Dependencies []Dependency // aware 依赖注入（按字段名排序）
 	Values       []Dependency // value 配置注入（按字段名排序）
 }
@@ -37,10 +45,12 @@ func (container *di) DescribeBean(beanName string) (desc BeanDescription, ok boo
 	deps := make([]Dependency, 0, len(def.awareMap))
 	for field, aware := range def.awareMap {
 		deps = append(deps, Dependency{
-			Field:     field,
-			Name:      aware.Name,
-			Type:      aware.Type,
-			Omitempty: aware.Omitempty,
+			Field:      field,
+			Name:       aware.Name,
+			Type:       aware.Type,
+			Omitempty:  aware.Omitempty,
+			Lazy:       aware.IsLazy,
+			Qualifiers: aware.Qualifiers,
 		})
 	}
 	slices.SortFunc(deps, func(a, b Dependency) int { return strings.Compare(a.Field, b.Field) })
@@ -53,16 +63,26 @@ func (container *di) DescribeBean(beanName string) (desc BeanDescription, ok boo
 		})
 	}
 	slices.SortFunc(values, func(a, b Dependency) int { return strings.Compare(a.Field, b.Field) })
+	var decorators []string
+	for _, d := range container.decoratorsOf(beanName, def.instanceType()) {
+		decorators = append(decorators, factoryName(d.fn))
+	}
 	return BeanDescription{
 		Name:         def.Name,
 		Type:         def.Type,
 		Factory:      def.factory.IsValid(),
+		Scope:        def.scope,
+		LazyInit:     def.lazyInit,
+		Module:       container.beanModules[beanName],
+		Qualifiers:   def.qualifiers,
+		DependsOn:    def.dependsOn,
+		Decorators:   decorators,
 		Dependencies: deps,
 		Values:       values,
 	}, true
 }
 
-// GetBeanDependencies 返回 bean 依赖的其他 bean 名称列表（命名 aware 注入，按名称排序）。
+// GetBeanDependencies 返回 bean 依赖的其他 bean 名称列表（命名 aware 注入与 DependsOn 显式声明，去重，按名称排序）。
 // slice/map 按类型收集的注入（Name 为空）不包含在内；definition 不存在时返回 ok=false。
 // 线程安全（读锁）。
 func (container *di) GetBeanDependencies(beanName string) (deps []string, ok bool) {
@@ -77,6 +97,7 @@ func (container *di) GetBeanDependencies(beanName string) (deps []string, ok boo
 			deps = append(deps, aware.Name)
 		}
 	}
+	deps = append(deps, def.dependsOn...)
 	slices.Sort(deps)
-	return deps, true
+	return slices.Compact(deps), true
 }
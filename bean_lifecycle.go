@@ -3,13 +3,15 @@ package di
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"unsafe"
 
 	"github.com/cheivin/di/van"
 )
 
-// wireValue 注入配置项
-func (container *di) wireValue(bean reflect.Value, def definition, prefix string) {
+// wireValue 注入配置项。任一字段转换失败时返回 false（LoadE 期间继续注入其余字段）。
+func (container *di) wireValue(bean reflect.Value, def definition, prefix string) (ok bool) {
+	ok = true
 	if len(def.valueMap) > 0 {
 		container.log.Info(fmt.Sprintf("wire value for bean %s(%s)", def.Name, def.Type.String()))
 	}
@@ -21,12 +23,13 @@ func (container *di) wireValue(bean reflect.Value, def definition, prefix string
 		}
 		castValue, err := van.Cast(value, valueInfo.Type)
 		if err != nil {
-			container.log.Fatal(fmt.Errorf("%w: %s(%s) wire value failed for %s(%s.%s), %s",
+			container.fail(def.Name, filedName, fmt.Errorf("%w: %s(%s) wire value failed for %s(%s.%s), %s",
 				ErrBean, valueName, valueInfo.Type.String(),
 				def.Name, def.Type.String(), filedName,
 				err.Error(),
 			))
-			return
+			ok = false
+			continue
 		}
 		val := reflect.ValueOf(castValue)
 		// 设置值
@@ -44,80 +47,187 @@ func (container *di) wireValue(bean reflect.Value, def definition, prefix string
 			bean.FieldByName(filedName).Set(val)
 		}
 	}
+	return
 }
 
-// instanceBean 创建bean指针对象 并注入value
+// instanceBean 创建bean指针对象 并注入value。
+// 失败时返回 nil（错误已通过 fail 上报）。
 func (container *di) instanceBean(def definition) any {
 	// 工厂模式：按入参类型注入依赖并调用工厂
 	if def.factory.IsValid() {
+		// 入参中的原型实例先登记在临时 owner 名下，工厂返回后转移给产物
+		owner := &pendingOwner{name: def.Name}
 		args := make([]reflect.Value, len(def.factoryArgs))
-		for i, argType := range def.factoryArgs {
-			argBean := container.resolveFactoryArg(argType)
-			if argBean == nil {
-				container.log.Fatal(fmt.Errorf("%w: factory arg %s notfound for %s",
-					ErrBean, argType.String(), def.Name))
-				return nil
-			}
-			args[i] = reflect.ValueOf(argBean)
+		if !container.resolveFuncArgs(def.Name, args, def.factoryArgs, def.argQualifiers, def.params, owner) {
+			container.destroyDependents(owner)
+			return nil
 		}
 		results := def.factory.Call(args)
 		container.log.Debug(fmt.Sprintf("factory instance for %s(%s)", def.Name, def.Type.String()))
-		return results[0].Interface()
+		// (T, error) / (T, func(), error)：最后一个返回值为 error
+		if n := len(results); n > 1 {
+			if err, _ := results[n-1].Interface().(error); err != nil {
+				container.destroyDependents(owner)
+				container.fail(def.Name, "", fmt.Errorf("%w: factory %s failed for %s: %w",
+					ErrBean, factoryName(def.factory), def.Name, err))
+				return nil
+			}
+		}
+		// 容器以实例指针跟踪 bean（cleanup、原型实例的 owner、finalizer）：返回接口的工厂，其动态值也必须是非 nil 指针
+		var invalid string
+		if results[0].IsNil() {
+			invalid = "returned nil"
+		} else if def.Type.Kind() == reflect.Interface && results[0].Elem().Kind() != reflect.Pointer {
+			invalid = fmt.Sprintf("returned non-pointer %s", results[0].Elem().Type().String())
+		}
+		if invalid != "" {
+			container.destroyDependents(owner)
+			if len(results) == 3 && !results[1].IsNil() {
+				results[1].Interface().(func())()
+			}
+			container.fail(def.Name, "", fmt.Errorf("%w: factory %s %s for %s",
+				ErrBean, factoryName(def.factory), invalid, def.Name))
+			return nil
+		}
+		bean := results[0].Interface()
+		if len(results) == 3 && !results[1].IsNil() {
+			container.addCleanup(def.Name, bean, results[1].Interface().(func()))
+		}
+		// 字段注入（WithFieldInjection）：注入值（失败已上报；aware 依赖在 processBean 中注入）
+		if def.injectFields {
+			container.wireValue(results[0].Elem(), def, "")
+		}
+		container.transferDependents(owner, bean)
+		return bean
 	}
 	container.log.Debug(fmt.Sprintf("reflect instance for %s(%s)", def.Name, def.Type.String()))
 	prototype := reflect.New(def.Type).Interface()
-	// 注入值
+	// 注入值（失败已上报；仍返回实例，以便继续收集 aware 注入的错误）
 	container.wireValue(reflect.ValueOf(prototype).Elem(), def, "")
 	return prototype
 }
 
-// resolveFactoryArg 按类型解析工厂入参：先按类型名查找，找不到则按类型匹配候选
-func (container *di) resolveFactoryArg(argType reflect.Type) any {
+// resolveFuncArgs 解析工厂/装饰函数的入参，填入 args 中尚未设置的位置（已设置的如被装饰的 bean 跳过）。
+// 参数结构体逐字段注入，其余按 resolveFactoryArg 解析；错误归属 beanName，任一入参失败时返回 false。
+// 入参中的原型实例登记到 owner 名下。
+func (container *di) resolveFuncArgs(beanName string, args []reflect.Value, argTypes []reflect.Type,
+	argQualifiers map[int][]string, params map[int]definition, owner any) (resolved bool) {
+	resolved = true
+	for i, argType := range argTypes {
+		if args[i].IsValid() {
+			continue
+		}
+		// 参数结构体：逐字段注入
+		if paramDef, ok := params[i]; ok {
+			paramDef.Name = beanName
+			param, wired := container.resolveParamStruct(paramDef, owner)
+			resolved = resolved && wired
+			args[i] = param
+			continue
+		}
+		argBean, err := container.resolveFactoryArg(argType, argQualifiers[i], owner)
+		if err == nil && argBean == nil {
+			err = fmt.Errorf("%w: factory arg %s notfound for %s",
+				ErrBean, aware{Type: argType, Qualifiers: argQualifiers[i]}.target(), beanName)
+		}
+		if err != nil {
+			container.fail(beanName, "", err)
+			resolved = false
+			continue
+		}
+		args[i] = reflect.ValueOf(argBean)
+	}
+	return
+}
+
+// resolveFactoryArg 按类型解析工厂入参：先按类型名查找，找不到则按类型匹配候选。
+// 要求限定标签（ArgQualifier）时直接按类型匹配，只保留具备全部标签的候选。
+// slice/map 入参同 aware 批量注入，收集所有匹配的 bean。
+// 未找到时返回 (nil, nil)，由调用方决定错误信息。owner 为入参中原型实例的归属。
+func (container *di) resolveFactoryArg(argType reflect.Type, qualifiers []string, owner any) (any, error) {
+	// []T / map[string]T：收集所有匹配的 bean（可为空）
+	if isCollectType(argType) {
+		return container.collectBeans(argType, qualifiers, owner).Interface(), nil
+	}
 	// 指针类型：按类型推断 beanName 查找
-	if argType.Kind() == reflect.Pointer {
+	if argType.Kind() == reflect.Pointer && len(qualifiers) == 0 {
 		beanName := GetBeanName(argType)
-		if bean, ok := container.findBeanByName(beanName); ok {
-			return bean
+		if bean, ok := container.findBeanByName(beanName, owner); ok {
+			return bean, nil
 		}
 	}
 	// 接口或按名未命中：按类型匹配（取 selector 选中的）
 	candidates := container.findBeanByType(argType)
+	if len(qualifiers) > 0 {
+		candidates = container.filterQualified(candidates, "", qualifiers)
+	}
 	if len(candidates) > 0 {
 		idx, err := container.selector.Select(candidates, argType)
 		if err != nil {
-			container.log.Fatal(fmt.Errorf("%w: factory arg select failed for %s, %s",
-				ErrBean, argType.String(), err.Error()))
-			return nil
+			return nil, fmt.Errorf("%w: factory arg select failed for %s, %s",
+				ErrBean, argType.String(), err.Error())
+		}
+		if bean := container.materialize(candidates[idx], owner); bean != nil {
+			return bean, nil
 		}
-		return candidates[idx].Bean
 	}
-	return nil
+	return nil, nil
 }
 
 // processBean 处理单个 bean 的依赖注入流程：
-// PreInitialize → wireBean（注入 aware 依赖）→ AfterPropertiesSet。
+// PreInitialize → wireBean（注入 aware 依赖）→ BeanPostProcessor.BeforeInit → AfterPropertiesSet → BeanPostProcessor.AfterInit。
 // 注入和回调都在锁外执行，允许 bean 回调内反向访问容器。
-func (container *di) processBean(prototype any, def definition) any {
+// 注入失败（LoadE 收集模式）时不触发 AfterPropertiesSet，避免回调读到未注入的字段。
+// owner 为注入的原型实例的归属（通常为 bean 自身）。
+// 返回后置处理器处理后的实例（未注册处理器或失败时为 prototype），replaced 表示是否被替换为另一个实例；
+// 生命周期回调始终作用于 prototype。
+func (container *di) processBean(prototype any, def definition, owner any) (_ any, replaced bool) {
 	// 注入前方法
 	container.preInitialize(def, prototype)
 
-	bean := reflect.ValueOf(prototype).Elem()
-	container.wireBean(bean, def)
+	bean := reflect.Indirect(reflect.ValueOf(prototype))
+	if !container.wireBean(bean, def, owner) || container.hasFailed(def.Name) {
+		return prototype, false
+	}
 
+	processed, replacedBefore := container.postProcess(def, prototype, true)
+	if processed == nil {
+		return prototype, false
+	}
 	// 注入后方法
 	container.afterPropertiesSet(def, prototype)
-	return prototype
+	processed, replacedAfter := container.postProcess(def, processed, false)
+	if processed == nil {
+		return prototype, false
+	}
+	return processed, replacedBefore || replacedAfter
 }
 
-// findBeanByName 根据名称查找bean
-func (container *di) findBeanByName(beanName string) (awareBean any, ok bool) {
+// findBeanByName 根据名称查找bean。
+// 原型作用域的定义每次查找都创建新实例，登记到 owner 名下；WithLazyInit 的单例首次查找时创建；
+// Load 期间待装饰的单例先完成装饰。
+// 本容器未找到时回退到父容器查找。
+func (container *di) findBeanByName(beanName string, owner any) (awareBean any, ok bool) {
 	container.mu.RLock()
-	defer container.mu.RUnlock()
 	// 从注册的bean中查找
 	if awareBean, ok = container.beanMap[beanName]; !ok {
 		// 从原型定义中查找
 		awareBean, ok = container.prototypeMap[beanName]
 	}
+	def, isDef := container.beanDefinitionMap[beanName]
+	container.mu.RUnlock()
+	if !ok && isDef && def.deferred() {
+		// 锁外创建（生命周期回调可能反向访问容器）
+		awareBean = container.newDeferredBean(def, owner)
+		ok = awareBean != nil
+	}
+	// Load 期间待装饰的单例：先完成注入与装饰，依赖方只拿到装饰后的实例
+	if ok && container.pendingCompletion(beanName) {
+		return container.completeBean(beanName)
+	}
+	if !ok && !isDef && container.parent != nil {
+		return container.parent.findBeanByName(beanName, container.parentOwner(owner))
+	}
 	return
 }
 
@@ -129,6 +239,8 @@ type BeanWithName struct {
 // findBeanByType 按 beanSort 注册顺序收集所有可赋值给 beanType 的 bean。
 // 锁内读取 beanMap/prototypeMap 快照，锁外打印日志，避免持锁调日志的潜在重入。
 // 不调用 findBeanByName 以免重复加锁。
+// 原型作用域的定义以占位实例参与匹配，注入前需经 materialize 创建真正的实例。
+// 本容器没有匹配项时回退到父容器查找。
 func (container *di) findBeanByType(beanType reflect.Type) []BeanWithName {
 	var beans []BeanWithName
 	// 根据排序遍历beanName查找
@@ -140,12 +252,19 @@ func (container *di) findBeanByType(beanType reflect.Type) []BeanWithName {
 			prototype, ok = container.prototypeMap[findBeanName]
 		}
 		if ok {
-			if reflect.TypeOf(prototype).AssignableTo(beanType) {
+			if container.exposedType(findBeanName, prototype).AssignableTo(beanType) {
 				beans = append(beans, BeanWithName{Name: findBeanName, Bean: prototype})
 			}
+		} else if def, isDef := container.beanDefinitionMap[findBeanName]; isDef && def.deferred() {
+			if def.instanceType().AssignableTo(beanType) {
+				beans = append(beans, BeanWithName{Name: findBeanName, Bean: def.placeholder()})
+			}
 		}
 	}
 	container.mu.RUnlock()
+	if len(beans) == 0 && container.parent != nil {
+		return container.parent.findBeanByType(beanType)
+	}
 	// 日志在锁外
 	for _, b := range beans {
 		container.log.Info(fmt.Sprintf("find interface %s implemented by %s(%T)",
@@ -155,62 +274,42 @@ func (container *di) findBeanByType(beanType reflect.Type) []BeanWithName {
 	return beans
 }
 
-// wireBean 注入单个依赖
-func (container *di) wireBean(bean reflect.Value, def definition) {
+// wireBean 注入单个依赖。任一字段注入失败时返回 false（LoadE 期间继续注入其余字段）。
+// 注入的原型作用域实例登记到 owner 名下。
+func (container *di) wireBean(bean reflect.Value, def definition, owner any) (wired bool) {
+	wired = true
 	if len(def.awareMap) > 0 {
 		container.log.Info(fmt.Sprintf("wire field for bean %s(%s)", def.Name, def.Type.String()))
 	}
 	for filedName, awareInfo := range def.awareMap {
+		// Lazy[T] 字段：只绑定解析方式，首次 Get 时才查找目标 bean
+		if awareInfo.IsLazy {
+			container.bindLazy(bean, def, filedName, awareInfo, owner)
+			continue
+		}
 		// slice/map 批量注入：收集所有可赋值给元素类型的 bean，不走单值选择
 		if awareInfo.IsSlice || awareInfo.IsMap {
-			candidates := container.findBeanByType(awareInfo.ElemType)
 			field := bean.FieldByName(filedName)
 			if container.unsafe {
 				field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
 			}
+			collected := container.collectBeans(awareInfo.Type, awareInfo.Qualifiers, owner)
+			field.Set(collected)
 			if awareInfo.IsSlice {
-				sliceVal := reflect.MakeSlice(awareInfo.Type, len(candidates), len(candidates))
-				for i, c := range candidates {
-					sliceVal.Index(i).Set(reflect.ValueOf(c.Bean))
-				}
-				field.Set(sliceVal)
 				container.log.Debug(fmt.Sprintf("wire slice field for %s(%s.%s) with %d beans",
-					def.Name, def.Type.String(), filedName, len(candidates)))
-			} else { // map[string]T
-				mapVal := reflect.MakeMapWithSize(awareInfo.Type, len(candidates))
-				for _, c := range candidates {
-					mapVal.SetMapIndex(reflect.ValueOf(c.Name), reflect.ValueOf(c.Bean))
-				}
-				field.Set(mapVal)
+					def.Name, def.Type.String(), filedName, collected.Len()))
+			} else {
 				container.log.Debug(fmt.Sprintf("wire map field for %s(%s.%s) with %d beans",
-					def.Name, def.Type.String(), filedName, len(candidates)))
+					def.Name, def.Type.String(), filedName, collected.Len()))
 			}
 			continue
 		}
 
-		var awareBean any
-		var ok bool
-
-		// 根据名称查找bean
-		awareBean, ok = container.findBeanByName(awareInfo.Name)
-		// 如果是接口类型
-		if awareInfo.IsInterface && !ok {
-			awareBeans := container.findBeanByType(awareInfo.Type)
-			if len(awareBeans) > 0 {
-				idx, err := container.selector.Select(awareBeans, awareInfo.Type)
-				if err != nil {
-					container.log.Fatal(fmt.Errorf("%w: select failed for %s(%s.%s), %s",
-						ErrBean, def.Name, def.Type.String(), filedName, err.Error()))
-					return
-				}
-				selectBean := awareBeans[idx]
-				awareBean = selectBean.Bean
-				ok = true
-				container.log.Info(fmt.Sprintf("%s(%T) will be set to %s(%s.%s)",
-					selectBean.Name, awareBean,
-					def.Name, def.Type.String(), filedName,
-				))
-			}
+		awareBean, ok, err := container.resolveAware(awareInfo, def, filedName, owner)
+		if err != nil {
+			container.fail(def.Name, filedName, err)
+			wired = false
+			continue
 		}
 
 		injectInfo := &InjectInfo{
@@ -224,6 +323,7 @@ func (container *di) wireBean(bean reflect.Value, def definition) {
 			IsSlice:     awareInfo.IsSlice,
 			IsMap:       awareInfo.IsMap,
 			ElemType:    awareInfo.ElemType,
+			Qualifiers:  awareInfo.Qualifiers,
 		}
 		switch bean.Interface().(type) {
 		case Injector:
@@ -237,25 +337,27 @@ func (container *di) wireBean(bean reflect.Value, def definition) {
 		if !ok {
 			if awareInfo.Omitempty {
 				container.log.Warn(fmt.Sprintf("Omitempty: dependent bean %s not found for %s(%s.%s)",
-					awareInfo.Name,
+					awareInfo.target(),
 					def.Name,
 					def.Type.String(),
 					filedName))
 				continue
 			}
-			container.log.Fatal(fmt.Errorf("%w: %s notfound for %s(%s.%s)",
+			container.fail(def.Name, filedName, fmt.Errorf("%w: %s notfound for %s(%s.%s)",
 				ErrBean,
-				awareInfo.Name,
+				awareInfo.target(),
 				def.Name,
 				def.Type.String(),
 				filedName))
+			wired = false
+			continue
 		}
 		value := reflect.ValueOf(awareBean)
 
 		// 类型检查
 		if awareInfo.IsPtr { // 指针类型
 			if !value.Type().AssignableTo(awareInfo.Type) {
-				container.log.Fatal(fmt.Errorf("%w: %s(%s) not match for %s(%s.%s) need type %s",
+				container.fail(def.Name, filedName, fmt.Errorf("%w: %s(%s) not match for %s(%s.%s) need type %s",
 					ErrBean,
 					awareInfo.Name, value.Type().String(),
 					def.Name,
@@ -263,11 +365,12 @@ func (container *di) wireBean(bean reflect.Value, def definition) {
 					filedName,
 					awareInfo.Type.String(),
 				))
-				return
+				wired = false
+				continue
 			}
 		} else { // 接口类型
 			if !value.Type().Implements(awareInfo.Type) {
-				container.log.Fatal(fmt.Errorf("%w: %s(%s) not implements interface %s for %s(%s.%s)",
+				container.fail(def.Name, filedName, fmt.Errorf("%w: %s(%s) not implements interface %s for %s(%s.%s)",
 					ErrBean,
 					awareInfo.Name, value.Type().String(),
 					awareInfo.Type.String(),
@@ -275,7 +378,8 @@ func (container *di) wireBean(bean reflect.Value, def definition) {
 					def.Type.String(),
 					filedName,
 				))
-				return
+				wired = false
+				continue
 			}
 		}
 
@@ -308,4 +412,64 @@ func (container *di) wireBean(bean reflect.Value, def definition) {
 			bean.FieldByName(filedName).Set(value)
 		}
 	}
+	return
+}
+
+// collectBeans 收集所有可赋值给 collectType（[]T 或 map[string]T）元素类型的 bean（可按限定标签筛选）。
+// slice 按 Ordered/WithOrder 排序，相同时保持注册顺序；map 以 beanName 为 key。
+func (container *di) collectBeans(collectType reflect.Type, qualifiers []string, owner any) reflect.Value {
+	candidates := container.findBeanByType(collectType.Elem())
+	if len(qualifiers) > 0 {
+		candidates = container.filterQualified(candidates, "", qualifiers)
+	}
+	for i := range candidates {
+		candidates[i].Bean = container.materialize(candidates[i], owner)
+	}
+	candidates = slices.DeleteFunc(candidates, func(c BeanWithName) bool { return c.Bean == nil })
+	if collectType.Kind() == reflect.Map {
+		mapVal := reflect.MakeMapWithSize(collectType, len(candidates))
+		for _, c := range candidates {
+			mapVal.SetMapIndex(reflect.ValueOf(c.Name), reflect.ValueOf(c.Bean))
+		}
+		return mapVal
+	}
+	container.sortByOrder(candidates)
+	sliceVal := reflect.MakeSlice(collectType, len(candidates), len(candidates))
+	for i, c := range candidates {
+		sliceVal.Index(i).Set(reflect.ValueOf(c.Bean))
+	}
+	return sliceVal
+}
+
+// resolveAware 查找单值 aware 依赖：先按名称查找，接口类型未找到时按类型查找并交由 BeanSelector 选择。
+// 声明了限定标签时直接按类型查找，只保留具备全部标签的候选。
+// 选择失败时返回错误；未找到返回 ok=false（由调用方按 omitempty 处理）。
+func (container *di) resolveAware(awareInfo aware, def definition, filedName string, owner any) (awareBean any, ok bool, err error) {
+	qualified := len(awareInfo.Qualifiers) > 0
+	if !qualified {
+		// 根据名称查找bean
+		awareBean, ok = container.findBeanByName(awareInfo.Name, owner)
+	}
+	// 如果是接口类型或限定标签
+	if (awareInfo.IsInterface || qualified) && !ok {
+		awareBeans := container.findBeanByType(awareInfo.Type)
+		if qualified {
+			awareBeans = container.filterQualified(awareBeans, awareInfo.Name, awareInfo.Qualifiers)
+		}
+		if len(awareBeans) > 0 {
+			idx, err := container.selector.Select(awareBeans, awareInfo.Type)
+			if err != nil {
+				return nil, false, fmt.Errorf("%w: select failed for %s(%s.%s), %s",
+					ErrBean, def.Name, def.Type.String(), filedName, err.Error())
+			}
+			selectBean := awareBeans[idx]
+			awareBean = container.materialize(selectBean, owner)
+			ok = awareBean != nil
+			container.log.Info(fmt.Sprintf("%s(%T) will be set to %s(%s.%s)",
+				selectBean.Name, awareBean,
+				def.Name, def.Type.String(), filedName,
+			))
+		}
+	}
+	return
 }
@@ -1,6 +1,9 @@
 package di
 
-import "reflect"
+import (
+	"context"
+	"reflect"
+)
 
 type (
 	// BeanName 返回beanName
@@ -39,6 +42,7 @@ type (
 		IsSlice     bool // 是否为 slice
 		IsMap       bool // 是否为 map[string]T
 		ElemType    reflect.Type // slice/map 的元素类型
+		Qualifiers  []string // 要求的限定标签
 	}
 	// Injector bean实例注入器
 	Injector interface {
@@ -73,4 +77,14 @@ type (
 	DisposableWithContainer interface {
 		Destroy(DI)
 	}
+
+	// Startable 在所有Bean的Initialized之后由Load按生命周期顺序调用，返回error时加载失败，已启动的Bean倒序回滚Stop。
+	Startable interface {
+		Start(ctx context.Context) error
+	}
+
+	// Stoppable 在Serve退出时、Destroy之前按启动倒序调用，错误汇总由ServeE返回。
+	Stoppable interface {
+		Stop(ctx context.Context) error
+	}
 )
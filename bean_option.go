@@ -0,0 +1,37 @@
+package di
+
+// BeanOption 注册选项，作用于 Provide/ProvideNamedBean/ProvideFunc 生成的 bean 定义。
+// 选项在 bean 自身实现的声明接口（如 BeanScope）之后应用，优先级更高。
+type BeanOption func(def *definition)
+
+// WithScope 声明 bean 的作用域，覆盖 bean 实现的 BeanScope 接口。
+func WithScope(scope Scope) BeanOption {
+	return func(def *definition) {
+		def.scope = scope
+	}
+}
+
+// WithLazyInit 声明单例 bean 延迟创建：Load 时跳过，首次被查找（Lazy[T].Get、GetBean、
+// 其他 bean 的 aware 注入等）时才实例化并走完整生命周期。对原型/请求作用域无影响。
+func WithLazyInit() BeanOption {
+	return func(def *definition) {
+		def.lazyInit = true
+	}
+}
+
+// WithFieldInjection 声明对工厂产物做字段注入：工厂返回后，按返回结构体的 value 标签注入配置，
+// 再在 AfterPropertiesSet 之前按 aware 标签注入依赖（规则同 Provide）。仅用于返回结构体指针的 ProvideFunc。
+func WithFieldInjection() BeanOption {
+	return func(def *definition) {
+		def.injectFields = true
+	}
+}
+
+// applyOptions 依次应用注册选项
+func (def *definition) applyOptions(opts []BeanOption) {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(def)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package di
+
+import (
+	"errors"
+	"testing"
+)
+
+type childDB struct{}
+
+type childNotifier interface{ Notify() string }
+
+type childMailer struct{}
+
+func (*childMailer) Notify() string { return "mail" }
+
+type childPlugin struct {
+	DB       *childDB        `aware:""`
+	Notifier childNotifier   `aware:""`
+	All      []childNotifier `aware:""`
+	Name     string          `value:"plugin.name"`
+	Region   string          `value:"app.region"`
+}
+
+func (*childPlugin) Destroy() { testRecorder.add("destroy-plugin") }
+
+// TestChild_ResolveFromParent 子容器本地找不到的依赖回退到父容器
+func TestChild_ResolveFromParent(t *testing.T) {
+	parent := New()
+	parent.RegisterBean(&childDB{})
+	parent.RegisterBean(&childMailer{})
+	parent.SetProperty("app.region", "eu")
+	parent.SetProperty("plugin.name", "shared")
+	parent.Load()
+
+	child := parent.NewChild()
+	child.SetProperty("plugin.name", "billing")
+	child.Provide(childPlugin{})
+	child.Load()
+
+	bean, ok := child.GetBean("childPlugin")
+	if !ok {
+		t.Fatal("expected childPlugin in child")
+	}
+	p := bean.(*childPlugin)
+	if p.DB == nil || p.Notifier == nil || len(p.All) != 1 {
+		t.Fatalf("expected parent beans injected, got %+v", p)
+	}
+	if p.Name != "billing" || p.Region != "eu" {
+		t.Fatalf("want layered properties (billing, eu), got (%s, %s)", p.Name, p.Region)
+	}
+	// 父容器看不到子容器的 bean，配置也不受子容器影响
+	if _, ok := parent.GetBean("childPlugin"); ok {
+		t.Fatal("child bean should not leak into parent")
+	}
+	if parent.GetProperty("plugin.name") != "shared" {
+		t.Fatal("child property should not leak into parent")
+	}
+	// 查询 API 回退到父容器
+	if _, ok := child.GetBean("childDB"); !ok {
+		t.Fatal("expected GetBean fallback to parent")
+	}
+	if _, ok := child.GetByType(&childDB{}); !ok {
+		t.Fatal("expected GetByType fallback to parent")
+	}
+	if len(child.GetByTypeAll((*childNotifier)(nil))) != 1 {
+		t.Fatal("expected GetByTypeAll fallback to parent")
+	}
+	if !child.HasBeanType(childDB{}) {
+		t.Fatal("expected HasBeanType fallback to parent")
+	}
+	all := child.Property().GetAll()
+	if app, ok := all["app"].(map[string]any); !ok || app["region"] != "eu" {
+		t.Fatalf("want merged properties, got %v", all)
+	}
+}
+
+// TestChild_LocalLifecycle 子容器的销毁只影响本地 bean
+func TestChild_LocalLifecycle(t *testing.T) {
+	testRecorder.reset()
+	parent := New()
+	parent.RegisterBean(&childDB{})
+	parent.RegisterBean(&childMailer{})
+	parent.Load()
+
+	child := parent.NewChild().(*di)
+	child.Provide(childPlugin{})
+	child.Load()
+	child.destroyBeans()
+
+	if len(testRecorder.events) != 1 || testRecorder.events[0] != "destroy-plugin" {
+		t.Fatalf("want only child bean destroyed, got %v", testRecorder.events)
+	}
+	if _, ok := parent.GetBean("childDB"); !ok {
+		t.Fatal("parent beans should survive child destroy")
+	}
+}
+
+// TestChild_ParentNotLoaded 父容器未加载时子容器不能加载
+func TestChild_ParentNotLoaded(t *testing.T) {
+	parent := New()
+	child := parent.NewChild()
+	if err := child.LoadE(); !errors.Is(err, ErrNotLoaded) {
+		t.Fatalf("want ErrNotLoaded, got %v", err)
+	}
+}
+
+type childCycleA struct {
+	B *childCycleB `aware:""`
+}
+
+type childCycleB struct {
+	A *childCycleA `aware:""`
+}
+
+// childProcessor 记录经过后置处理的 bean
+type childProcessor struct{ names []string }
+
+func (p *childProcessor) BeforeInit(beanName string, bean any) (any, error) {
+	p.names = append(p.names, beanName)
+	return bean, nil
+}
+
+func (*childProcessor) AfterInit(_ string, bean any) (any, error) { return bean, nil }
+
+// TestChild_InheritedSettings 子容器继承循环依赖检测设置；装饰函数与后置处理器不继承，只作用于父容器创建的 bean
+func TestChild_InheritedSettings(t *testing.T) {
+	processor := &childProcessor{}
+	parent := New()
+	parent.WithCircularCheck(true)
+	parent.AddBeanPostProcessor(processor)
+	parent.Decorate(func(*childDB) *childDB { return &childDB{} })
+	parent.Provide(childDB{})
+	parent.Load()
+
+	child := parent.NewChild()
+	child.Provide(childCycleA{})
+	child.Provide(childCycleB{})
+	if err := child.LoadE(); !errors.Is(err, ErrCircularDependency) {
+		t.Fatalf("want circular check inherited, got %v", err)
+	}
+
+	child = parent.NewChild()
+	local := &childDB{}
+	child.RegisterNamedBean("localDB", local)
+	child.Provide(childMailer{})
+	if err := child.LoadE(); err != nil {
+		t.Fatalf("want child loaded, got %v", err)
+	}
+	if len(processor.names) != 1 || processor.names[0] != "childDB" {
+		t.Fatalf("want parent post processor applied to parent beans only, got %v", processor.names)
+	}
+	if got, _ := child.GetBean("localDB"); got != local {
+		t.Fatal("want parent decorator not applied to child beans")
+	}
+}
@@ -101,3 +101,38 @@ func itoa(n int) string {
 	}
 	return string(buf[i:])
 }
+
+// TestConcurrent_LookupDuringLoad Load 进行中其他 goroutine 并发查找（读取 loaded 状态），-race 下不应报错
+func TestConcurrent_LookupDuringLoad(t *testing.T) {
+	c := New()
+	c.RegisterBean(&concDB{Name: "loading"})
+	c.Provide(concService{})
+
+	done := make(chan struct{})
+	var started, wg sync.WaitGroup
+	for range 4 {
+		started.Add(1)
+		wg.Go(func() {
+			started.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				_, _ = Get[*concDB](c)
+				c.GetBean("concService")
+				c.GetByTypeAll(&concService{})
+			}
+		})
+	}
+	// 查找 goroutine 已在运行时开始 Load
+	started.Wait()
+	c.Load()
+	close(done)
+	wg.Wait()
+
+	if svc, err := Get[*concService](c); err != nil || svc.DB == nil {
+		t.Fatalf("want service wired after Load, got %v, %v", svc, err)
+	}
+}
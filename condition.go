@@ -0,0 +1,141 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// conditionPhase 条件的评估阶段：按阶段依次评估，后一阶段能看到前一阶段淘汰的结果
+type conditionPhase int
+
+const (
+	phaseEnv         conditionPhase = iota // 配置项 / 自定义断言
+	phaseBean                              // ConditionalOnBean
+	phaseMissingBean                       // ConditionalOnMissingBean（后备实现，最后评估）
+)
+
+// condition 注册条件，Load 时在实例化之前评估；不满足的定义被移除
+type condition struct {
+	phase conditionPhase
+	desc  string
+	// skip 为评估时需忽略的 beanName（自身、尚未评估的后备定义）
+	match func(container *di, skip func(beanName string) bool) bool
+}
+
+// ConditionalOnProperty 仅当配置项 key 的值等于 havingValue（忽略大小写）时注册。
+// havingValue 为空时，配置项存在且不为 "false" 即满足。
+func ConditionalOnProperty(key, havingValue string) BeanOption {
+	desc := fmt.Sprintf("property %s=%s", key, havingValue)
+	return withCondition(phaseEnv, desc, func(container *di, _ func(string) bool) bool {
+		value := container.valueStore.Get(key)
+		if value == nil {
+			return false
+		}
+		if havingValue == "" {
+			return !strings.EqualFold(fmt.Sprint(value), "false")
+		}
+		return strings.EqualFold(fmt.Sprint(value), havingValue)
+	})
+}
+
+// Conditional 仅当自定义断言返回 true 时注册。断言在 Load 时、实例化之前调用，
+// 可读取配置项与 HasBeanType 等定义级信息，不能依赖 bean 实例。
+func Conditional(predicate func(c DI) bool) BeanOption {
+	return withCondition(phaseEnv, "custom", func(container *di, _ func(string) bool) bool {
+		return predicate(container)
+	})
+}
+
+// ConditionalOnBean 仅当容器中存在 beanType 类型的 bean 时注册（传参规则同 HasBeanType）。
+// 在配置项/自定义条件之后评估，能看到被这些条件淘汰的结果。
+func ConditionalOnBean(beanType any) BeanOption {
+	desc := fmt.Sprintf("bean %s", reflect.TypeOf(beanType))
+	return withCondition(phaseBean, desc, func(container *di, skip func(string) bool) bool {
+		return container.hasBeanTypeExcept(beanType, skip)
+	})
+}
+
+// ConditionalOnMissingBean 仅当容器中不存在 beanType 类型的其他 bean 时注册，用于声明后备实现：
+// 应用注册了同类型的 bean 时后备定义被移除；同名的定义/实例会静默替换后备定义（不报重复）。
+// 最后评估；多个后备定义之间按注册顺序保留第一个。
+func ConditionalOnMissingBean(beanType any) BeanOption {
+	desc := fmt.Sprintf("missing bean %s", reflect.TypeOf(beanType))
+	return withCondition(phaseMissingBean, desc, func(container *di, skip func(string) bool) bool {
+		return !container.hasBeanTypeExcept(beanType, skip)
+	})
+}
+
+func withCondition(phase conditionPhase, desc string, match func(container *di, skip func(string) bool) bool) BeanOption {
+	return func(def *definition) {
+		def.conditions = append(def.conditions, condition{phase: phase, desc: desc, match: match})
+	}
+}
+
+// fallback 判断定义是否为后备实现（声明了 ConditionalOnMissingBean）
+func (def definition) fallback() bool {
+	return slices.ContainsFunc(def.conditions, func(c condition) bool { return c.phase == phaseMissingBean })
+}
+
+// evaluateConditions 按阶段、按注册顺序评估所有定义的注册条件，移除不满足的定义。
+// 条件回调可能反向访问容器，在锁外执行。
+func (container *di) evaluateConditions() {
+	for phase := phaseEnv; phase <= phaseMissingBean; phase++ {
+		container.mu.RLock()
+		names := slices.Clone(container.beanSort)
+		// 尚未评估的后备定义不计入其他后备定义的 OnMissingBean 判断
+		pending := map[string]bool{}
+		for _, name := range names {
+			if def, ok := container.beanDefinitionMap[name]; ok && phase == phaseMissingBean && def.fallback() {
+				pending[name] = true
+			}
+		}
+		container.mu.RUnlock()
+
+		for _, name := range names {
+			container.mu.RLock()
+			def, ok := container.beanDefinitionMap[name]
+			container.mu.RUnlock()
+			if !ok {
+				continue
+			}
+			skip := func(beanName string) bool { return beanName == name || pending[beanName] }
+			for _, cond := range def.conditions {
+				if cond.phase == phase && !cond.match(container, skip) {
+					container.log.Info(fmt.Sprintf("skip bean %s(%s): condition %s not matched", name, def.Type.String(), cond.desc))
+					container.removeDefinition(name)
+					break
+				}
+			}
+			delete(pending, name)
+		}
+	}
+}
+
+// removeDefinition 移除定义及其注册顺序
+func (container *di) removeDefinition(beanName string) {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	delete(container.beanDefinitionMap, beanName)
+	container.beanSort = slices.DeleteFunc(container.beanSort, func(name string) bool { return name == beanName })
+}
+
+// resolveFallback 处理与后备定义同名的注册（调用方持写锁）：
+// 已有同名后备定义、新定义不是后备定义时由新定义替换（保留原注册位置），返回 true；
+// 新定义是后备定义且已有同名实例/定义时丢弃新定义，返回 true。其余情况返回 false，按常规做重复检查。
+func (container *di) resolveFallback(def definition) bool {
+	if existDef, exist := container.beanDefinitionMap[def.Name]; exist && existDef.fallback() && !def.fallback() {
+		container.log.Info(fmt.Sprintf("fallback bean %s(%s) replaced by %s", def.Name, existDef.Type.String(), def.Type.String()))
+		container.beanDefinitionMap[def.Name] = def
+		container.recordModule(def.Name)
+		return true
+	}
+	_, isBean := container.beanMap[def.Name]
+	_, isDef := container.beanDefinitionMap[def.Name]
+	if def.fallback() && (isBean || isDef) {
+		container.log.Info(fmt.Sprintf("fallback bean %s(%s) skipped, name already registered", def.Name, def.Type.String()))
+		return true
+	}
+	return false
+}
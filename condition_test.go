@@ -0,0 +1,123 @@
+package di
+
+import (
+	"testing"
+)
+
+type condStorage interface{ Kind() string }
+
+type condMemStorage struct{ _ int }
+
+func (*condMemStorage) Kind() string { return "mem" }
+
+type condDiskStorage struct{ _ int }
+
+func (*condDiskStorage) Kind() string { return "disk" }
+
+type condRedisStorage struct{ _ int }
+
+func (*condRedisStorage) Kind() string { return "redis" }
+
+// condNamedFallback / condNamedApp 同名（storage）的后备与应用实现
+type condNamedFallback struct{ _ int }
+
+func (condNamedFallback) BeanName() string { return "storage" }
+
+type condNamedApp struct{ _ int }
+
+func (condNamedApp) BeanName() string { return "storage" }
+
+type condMetrics struct{ _ int }
+
+// TestCondition_Property 按配置项注册
+func TestCondition_Property(t *testing.T) {
+	c := New()
+	c.SetProperty("storage.type", "Redis")
+	c.Provide(condRedisStorage{}, ConditionalOnProperty("storage.type", "redis"))
+	c.Provide(condDiskStorage{}, ConditionalOnProperty("storage.type", "disk"))
+	c.Provide(condMetrics{}, ConditionalOnProperty("metrics.enabled", ""))
+	c.Load()
+
+	if _, ok := c.GetBean("condRedisStorage"); !ok {
+		t.Fatal("expected condRedisStorage registered")
+	}
+	if _, ok := c.GetBean("condDiskStorage"); ok {
+		t.Fatal("condDiskStorage should be skipped")
+	}
+	if _, ok := c.GetBean("condMetrics"); ok {
+		t.Fatal("condMetrics should be skipped when property missing")
+	}
+	if len(c.GetBeanNames()) != 1 {
+		t.Fatalf("want skipped definitions removed, got %v", c.GetBeanNames())
+	}
+}
+
+// TestCondition_MissingBeanFallback 后备实现被应用实现静默替换
+func TestCondition_MissingBeanFallback(t *testing.T) {
+	// 应用注册了同类型实现：后备被移除
+	c := New()
+	c.Provide(condMemStorage{}, ConditionalOnMissingBean((*condStorage)(nil)))
+	c.Provide(condDiskStorage{})
+	c.Load()
+	if all := c.GetByTypeAll((*condStorage)(nil)); len(all) != 1 || all[0].Name != "condDiskStorage" {
+		t.Fatalf("want only app storage, got %v", all)
+	}
+
+	// 没有应用实现：多个后备保留第一个
+	c = New()
+	c.Provide(condMemStorage{}, ConditionalOnMissingBean((*condStorage)(nil)))
+	c.Provide(condRedisStorage{}, ConditionalOnMissingBean((*condStorage)(nil)))
+	c.Load()
+	if all := c.GetByTypeAll((*condStorage)(nil)); len(all) != 1 || all[0].Name != "condMemStorage" {
+		t.Fatalf("want first fallback kept, got %v", all)
+	}
+
+	// 被其他条件淘汰的实现不算存在
+	c = New()
+	c.Provide(condDiskStorage{}, ConditionalOnProperty("storage.type", "disk"))
+	c.Provide(condMemStorage{}, ConditionalOnMissingBean((*condStorage)(nil)))
+	c.Load()
+	if _, ok := c.GetBean("condMemStorage"); !ok {
+		t.Fatal("want fallback kept when app implementation is skipped")
+	}
+}
+
+// TestCondition_SameNameOverride 同名注册静默替换后备定义（与注册顺序无关）
+func TestCondition_SameNameOverride(t *testing.T) {
+	c := New()
+	c.Provide(condNamedFallback{}, ConditionalOnMissingBean(condNamedFallback{}))
+	c.Provide(condNamedApp{})
+	c.Load()
+	if bean, _ := c.GetBean("storage"); bean == nil {
+		t.Fatal("expected storage bean")
+	} else if _, ok := bean.(*condNamedApp); !ok {
+		t.Fatalf("want app implementation, got %T", bean)
+	}
+
+	c = New()
+	c.RegisterBean(&condNamedApp{})
+	c.Provide(condNamedFallback{}, ConditionalOnMissingBean(condNamedFallback{}))
+	c.Load()
+	if bean, _ := c.GetBean("storage"); bean == nil {
+		t.Fatal("expected storage bean")
+	} else if _, ok := bean.(*condNamedApp); !ok {
+		t.Fatalf("want registered instance kept, got %T", bean)
+	}
+}
+
+// TestCondition_OnBeanAndCustom ConditionalOnBean 与自定义断言
+func TestCondition_OnBeanAndCustom(t *testing.T) {
+	c := New()
+	c.SetProperty("env", "prod")
+	c.Provide(condDiskStorage{}, Conditional(func(c DI) bool { return c.GetProperty("env") == "prod" }))
+	c.Provide(condMetrics{}, ConditionalOnBean(&condDiskStorage{}))
+	c.Provide(condRedisStorage{}, ConditionalOnBean(&condMemStorage{}))
+	c.Load()
+
+	if _, ok := c.GetBean("condMetrics"); !ok {
+		t.Fatal("expected condMetrics registered when condDiskStorage present")
+	}
+	if _, ok := c.GetBean("condRedisStorage"); ok {
+		t.Fatal("condRedisStorage should be skipped when condMemStorage absent")
+	}
+}
@@ -1,6 +1,9 @@
 package di
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // DI 是依赖注入容器的核心接口。
 // 通过链式方法注册 bean、设置配置，最后调用 Load 加载、Serve 运行。
@@ -14,6 +17,30 @@ type DI interface {
 	// WithCircularCheck 开启/关闭循环依赖检测，默认关闭（指针循环依赖可正常注入）
 	WithCircularCheck(enable bool) DI
 
+	// WithDependencyOrder 开启/关闭按依赖拓扑顺序触发生命周期（构造、AfterPropertiesSet、Initialized，销毁倒序），默认按注册顺序
+	WithDependencyOrder(enable bool) DI
+
+	// WithParallelInit 设置并行触发 Initialized 的 worker 数：无依赖关系的 bean 并发执行，依赖先于依赖方完成；<= 1 时串行（默认）
+	WithParallelInit(workers int) DI
+
+	// WithStartTimeout 设置 Startable.Start 的单个 bean 超时与整体超时，0 表示不限
+	WithStartTimeout(perBean, total time.Duration) DI
+
+	// WithStopTimeout 设置 Stoppable.Stop 的单个 bean 超时与整体超时，0 表示不限
+	WithStopTimeout(perBean, total time.Duration) DI
+
+	// WithFactoryFieldInjection 对所有返回结构体指针的 ProvideFunc 工厂开启/关闭字段注入，默认关闭
+	WithFactoryFieldInjection(enable bool) DI
+
+	// AllowBeanOverriding 开启/关闭同名覆盖：开启后重复注册同名 bean 时后注册的替换先注册的，默认关闭
+	AllowBeanOverriding(enable bool) DI
+
+	// WithProfiles 设置激活的 profile，覆盖 di.profiles.active 配置项
+	WithProfiles(profiles ...string) DI
+
+	// ActiveProfiles 返回激活的 profile，未激活任何 profile 时为 ["default"]
+	ActiveProfiles() []string
+
 	// Log 设置容器的日志实现
 	Log(log Log) DI
 
@@ -23,22 +50,43 @@ type DI interface {
 	// RegisterNamedBean 以指定名称注册一个已实例化的 bean
 	RegisterNamedBean(name string, bean any) DI
 
-	// Provide 注册结构体原型（值类型），容器在 Load 时反射实例化为指针
-	Provide(prototype any) DI
+	// Provide 注册结构体原型（值类型），容器在 Load 时反射实例化为指针。opts 为注册选项（如 WithScope）
+	Provide(prototype any, opts ...BeanOption) DI
 
 	// ProvideNamedBean 以指定名称注册结构体原型
-	ProvideNamedBean(beanName string, prototype any) DI
+	ProvideNamedBean(beanName string, prototype any, opts ...BeanOption) DI
 
 	// ProvideFunc 注册工厂函数，容器按入参类型注入依赖，用返回值作为 bean。
-	ProvideFunc(fn any) DI
+	ProvideFunc(fn any, opts ...BeanOption) DI
+
+	// ProvideNamedFunc 以指定名称注册工厂函数
+	ProvideNamedFunc(beanName string, fn any, opts ...BeanOption) DI
+
+	// Decorate 注册装饰函数 func(T, deps...) T，包装所有按类型匹配的 bean
+	Decorate(fn any) DI
+
+	// DecorateNamed 注册只作用于 beanName 的装饰函数
+	DecorateNamed(beanName string, fn any) DI
 
-	// GetBean 按名称获取 bean 实例
+	// AddBeanPostProcessor 注册 bean 后置处理器，对容器创建的每个 bean 在 AfterPropertiesSet 前后调用
+	AddBeanPostProcessor(processors ...BeanPostProcessor) DI
+
+	// Override 以实例替换已注册的同名 bean（实例或定义），常用于测试替身
+	Override(beanName string, bean any) DI
+
+	// OverrideType 以实例替换按类型唯一匹配的已注册 bean
+	OverrideType(beanType any, bean any) DI
+
+	// Install 安装模块（打包的注册、默认配置与子模块），同一模块只安装一次
+	Install(mods ...*Module) DI
+
+	// GetBean 按名称获取 bean 实例；原型作用域的 bean 每次返回新实例
 	GetBean(beanName string) (bean any, ok bool)
 
 	// GetByType 按类型获取单个 bean（返回第一个匹配项）
 	GetByType(beanType any) (bean any, ok bool)
 
-	// GetByTypeAll 按类型获取所有匹配的 bean（实例），按注册顺序返回。
+	// GetByTypeAll 按类型获取所有匹配的 bean（实例），按排序值（Ordered/WithOrder）升序返回，相同时按注册顺序。
 	// 注意：Serve 退出销毁 bean 后实例已从容器移除，基于实例的查询返回空；
 	// 基于定义的查询（GetBeanNames/DescribeBean/GetBeanDependencies）不受影响
 	GetByTypeAll(beanType any) (beans []BeanWithName)
@@ -54,9 +102,12 @@ type DI interface {
 	// DescribeBean 返回 bean 定义的只读描述（仅原型/工厂 bean 有定义）；不存在时返回 ok=false
 	DescribeBean(beanName string) (desc BeanDescription, ok bool)
 
-	// GetBeanDependencies 返回 bean 依赖的其他 bean 名称列表（命名 aware 注入，按名称排序）
+	// GetBeanDependencies 返回 bean 依赖的其他 bean 名称列表（命名 aware 注入与 DependsOn 显式声明，去重，按名称排序）
 	GetBeanDependencies(beanName string) (deps []string, ok bool)
 
+	// GetBeanModule 返回注册 bean 的模块名称；非模块注册或 bean 不存在时返回 ok=false
+	GetBeanModule(beanName string) (module string, ok bool)
+
 	// NewBean 按类型创建新实例（非容器单例），走完整生命周期
 	NewBean(beanType any) (bean any)
 
@@ -81,6 +132,9 @@ type DI interface {
 	// SetPropertyMap 批量设置配置项
 	SetPropertyMap(properties map[string]any) DI
 
+	// SetProfilePropertyMap 设置 profile 专属配置项，该 profile 激活时在 Load 开始前覆盖基础配置
+	SetProfilePropertyMap(profile string, properties map[string]any) DI
+
 	// AutoMigrateEnv 读取所有环境变量注入配置（key 中 _ 转为 .）
 	AutoMigrateEnv() DI
 
@@ -93,9 +147,21 @@ type DI interface {
 	// Load 加载容器：实例化、注入依赖、触发生命周期。重复调用会 panic
 	Load()
 
-	// Serve 阻塞等待 ctx 结束，然后倒序销毁所有 bean
+	// LoadE 加载容器并收集所有装配错误，以 errors.Join 合并返回；失败后容器进入失败状态
+	LoadE() error
+
+	// Serve 阻塞等待 ctx 结束，然后按阶段（Phased）与生命周期顺序倒序停止（Stop）并销毁所有 bean
 	Serve(ctx context.Context)
 
-	// Context 返回容器的 context（Serve 时设置）
+	// ServeE 同 Serve，未加载或加载失败时返回错误而非 panic；退出时各 bean 的 Stop 错误合并返回
+	ServeE(ctx context.Context) error
+
+	// Context 返回容器的 context（Serve 时设置；请求作用域视图为对应请求的 context）
 	Context() context.Context
+
+	// NewChild 创建子容器：bean/配置/生命周期本地化，本地找不到的 bean 与配置回退到父容器
+	NewChild() DI
+
+	// Scope 返回 ctx 对应的请求作用域视图：ScopeRequest 的 bean 按 context 各创建一次，context 结束时销毁
+	Scope(ctx context.Context) DI
 }
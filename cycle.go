@@ -2,7 +2,10 @@ package di
 
 import (
 	"errors"
+	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -37,42 +40,216 @@ func (e *circularError) Unwrap() error {
 //
 // 仅在 Load() 启动期调用，无需加锁（调用方独占容器）。
 func (container *di) checkCircularDependency() error {
-	// 收集每个 bean 依赖的 beanName 集合（去重）
+	return container.findCycle(container.dependencyGraph(), func(string) bool { return true })
+}
+
+// checkDeferredCycle 检测按需创建的 bean（原型作用域、WithLazyInit 单例）之间的环。
+// 原型 bean 每个注入点都创建新实例，原型之间成环会无限递归创建；延迟单例在创建完成前不可见，
+// 成环会重入自身的创建。因此无论是否开启 WithCircularCheck 都会检测；
+// 环中只要有一个 Load 时创建的单例即可正常注入（单例先实例化再注入），不在此列。
+func (container *di) checkDeferredCycle() error {
+	return container.findCycle(container.dependencyGraph(), func(name string) bool {
+		return container.beanDefinitionMap[name].deferred()
+	})
+}
+
+// dependencyGraph 收集每个 bean 依赖的 beanName 集合（去重，含 DependsOn 显式声明的依赖），
+// 只包含能映射到已有 definition 的依赖；否则注入期会报 notfound，无需在此判环。
+// Lazy[T] 字段在首次 Get 时才解析，不构成注入期的依赖，不计入。
+func (container *di) dependencyGraph() map[string][]string {
+	return container.buildGraph(func(def definition) []aware {
+		awares := append(slices.Collect(maps.Values(def.awareMap)), def.factoryArgAwares()...)
+		return append(awares, def.dependsOnAwares()...)
+	})
+}
+
+// factoryGraph 收集每个 bean 在被依赖方取用前必须就绪的 beanName 集合：工厂入参（含参数结构体字段）；
+// 待装饰的单例还包括字段注入与装饰函数的入参（装饰完成后才能交给依赖方）。
+// 普通 bean 的字段注入在实例化之后进行，不计入。用于检测无法构造的环。
+func (container *di) factoryGraph() map[string][]string {
+	return container.buildGraph(func(def definition) []aware {
+		return append(def.factoryArgAwares(), container.decorationAwares(def)...)
+	})
+}
+
+// instantiationGraph 同 factoryGraph，但计入所有待完成单例（含仅经后置处理的）的字段注入与 DependsOn 声明，
+// 使其依赖先于取用它的工厂实例化。仅用于决定实例化顺序（允许成环）。
+func (container *di) instantiationGraph() map[string][]string {
+	return container.buildGraph(func(def definition) []aware {
+		awares := append(def.factoryArgAwares(), container.completionAwares(def)...)
+		return append(awares, def.dependsOnAwares()...)
+	})
+}
+
+// buildGraph 按 awares 给出的依赖信息构建依赖图（去重，跳过 Lazy 与不存在的定义）
+func (container *di) buildGraph(awares func(def definition) []aware) map[string][]string {
 	deps := make(map[string][]string, len(container.beanDefinitionMap))
 	for name, def := range container.beanDefinitionMap {
 		seen := map[string]struct{}{}
-		for _, a := range def.awareMap {
+		for _, a := range awares(def) {
+			if a.IsLazy {
+				continue
+			}
 			for _, depName := range container.resolveDepNames(a, name) {
 				if _, ok := seen[depName]; ok {
 					continue
 				}
-				// 只关心能映射到已有 definition 的依赖；
-				// 否则注入期会报 notfound，无需在此判环
 				if _, exists := container.beanDefinitionMap[depName]; exists {
 					seen[depName] = struct{}{}
 					deps[name] = append(deps[name], depName)
 				}
 			}
 		}
-		// 工厂入参也算依赖（按类型推断名称）
-		for _, argType := range def.factoryArgs {
-			a := aware{Name: GetBeanName(argType), Type: argType}
-			if argType.Kind() == reflect.Interface {
-				a.Name = ""
-				a.IsInterface = true
+	}
+	return deps
+}
+
+// factoryArgAwares 将工厂入参转为依赖信息
+func (def definition) factoryArgAwares() []aware {
+	return argAwares(def.factoryArgs, 0, def.argQualifiers, def.params)
+}
+
+// argAwares 将工厂/装饰函数的入参转为依赖信息：按类型推断名称，接口/限定标签按类型匹配，
+// slice/map 按元素类型收集，参数结构体展开为其字段。下标 from 之前的入参（如被装饰的 bean）跳过
+func argAwares(argTypes []reflect.Type, from int, argQualifiers map[int][]string, params map[int]definition) []aware {
+	var awares []aware
+	for i := from; i < len(argTypes); i++ {
+		argType := argTypes[i]
+		if paramDef, ok := params[i]; ok {
+			awares = append(awares, slices.Collect(maps.Values(paramDef.awareMap))...)
+			continue
+		}
+		a := aware{Type: argType, Qualifiers: argQualifiers[i]}
+		if isCollectType(argType) {
+			a.IsSlice = argType.Kind() == reflect.Slice
+			a.IsMap = !a.IsSlice
+			a.ElemType = argType.Elem()
+		} else if argType.Kind() == reflect.Interface || len(a.Qualifiers) > 0 {
+			a.IsInterface = argType.Kind() == reflect.Interface
+		} else {
+			a.Name = GetBeanName(argType)
+		}
+		awares = append(awares, a)
+	}
+	return awares
+}
+
+// checkFactoryCycle 检测工厂入参（及待装饰 bean 的依赖）之间的环：工厂调用前入参必须已经创建，
+// 装饰后的 bean 交给依赖方前必须完成注入，成环时无法构造，始终检测。
+func (container *di) checkFactoryCycle() error {
+	if err := container.findCycle(container.factoryGraph(), func(string) bool { return true }); err != nil {
+		return fmt.Errorf("%w (factory arguments or decorated beans, cannot be constructed)", err)
+	}
+	return nil
+}
+
+// instantiationOrder 返回 Load 时创建的单例定义的实例化顺序：按生命周期顺序，
+// 工厂入参依赖的定义（经由按需创建的定义间接依赖的也算）排在工厂之前。
+// 结构体原型实例化不依赖其他 bean，保持注册顺序。需先经 checkFactoryCycle 确认无环。
+// 由 initializeBeans 在创建任何单例之前调用，此时不会有回调并发注册 bean，故直接读取 beanDefinitionMap。
+func (container *di) instantiationOrder() []definition {
+	graph := container.instantiationGraph()
+	visited := make(map[string]bool, len(container.beanDefinitionMap))
+	var order []definition
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, depName := range graph[name] {
+			visit(depName)
+		}
+		// 请求作用域由 Scope(ctx) 视图创建；原型与 WithLazyInit 的单例按需创建
+		if def := container.beanDefinitionMap[name]; def.scope == ScopeSingleton && !def.lazyInit {
+			order = append(order, def)
+		}
+	}
+	for _, name := range container.order {
+		if _, ok := container.beanDefinitionMap[name]; ok {
+			visit(name)
+		}
+	}
+	return order
+}
+
+// lifecycleOrder 返回 Load 时确定的生命周期顺序：按阶段（Phased/WithPhase）升序分组，
+// 阶段内默认为注册顺序（DependsOn 声明的依赖提前），开启 WithDependencyOrder 时为完整依赖图的拓扑顺序。
+func (container *di) lifecycleOrder() []string {
+	if !container.dependencyOrder {
+		return container.sortByPhase(container.topologicalOrder(container.dependsOnOf))
+	}
+	return container.sortByPhase(container.topologicalOrder(container.lifecycleDeps))
+}
+
+// topologicalOrder 按 deps 给出的依赖对所有 bean（含直接注册的实例）做拓扑排序：
+// 被依赖的 bean 在前，无依赖关系的保持注册顺序，环内按 DFS 首次访问的顺序。
+// 不加锁：只在 Load 的 goroutine 中、Initialized 回调开始之前调用（lifecycleOrder、initializedParallel 划分任务），
+// 此时没有并发写入 beanSort 与各 map 的回调。
+func (container *di) topologicalOrder(deps func(beanName string) []string) []string {
+	visited := make(map[string]bool, len(container.beanSort))
+	order := make([]string, 0, len(container.beanSort))
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, depName := range deps(name) {
+			// DependsOn 可声明父容器的 bean，不在本容器排序
+			_, isBean := container.beanMap[depName]
+			_, isDef := container.beanDefinitionMap[depName]
+			if isBean || isDef {
+				visit(depName)
 			}
-			for _, depName := range container.resolveDepNames(a, name) {
-				if _, ok := seen[depName]; ok {
-					continue
-				}
-				if _, exists := container.beanDefinitionMap[depName]; exists {
-					seen[depName] = struct{}{}
-					deps[name] = append(deps[name], depName)
-				}
+		}
+		order = append(order, name)
+	}
+	for _, name := range container.beanSort {
+		visit(name)
+	}
+	return order
+}
+
+// lifecycleDeps 返回 beanName 在生命周期上依赖的 beanName（去重，跳过 Lazy 与自身）
+func (container *di) lifecycleDeps(beanName string) []string {
+	var awares []aware
+	for _, depName := range container.dependsOnOf(beanName) {
+		awares = append(awares, aware{Name: depName})
+	}
+	if def, ok := container.beanDefinitionMap[beanName]; ok {
+		awares = append(awares, slices.Collect(maps.Values(def.awareMap))...)
+		awares = append(awares, def.factoryArgAwares()...)
+		for _, d := range container.decoratorsOf(beanName, def.instanceType()) {
+			awares = append(awares, argAwares(d.args, 1, nil, d.params)...)
+		}
+	} else if bean, ok := container.beanMap[beanName]; ok {
+		for _, d := range container.decoratorsOf(beanName, container.exposedType(beanName, bean)) {
+			awares = append(awares, argAwares(d.args, 1, nil, d.params)...)
+		}
+	}
+	var deps []string
+	for _, a := range awares {
+		if a.IsLazy {
+			continue
+		}
+		for _, depName := range container.resolveDepNames(a, beanName) {
+			_, isBean := container.beanMap[depName]
+			_, isDef := container.beanDefinitionMap[depName]
+			if depName != beanName && (isBean || isDef) && !slices.Contains(deps, depName) {
+				deps = append(deps, depName)
 			}
 		}
 	}
+	// awareMap 遍历顺序随机：按注册顺序访问依赖，保证结果确定
+	slices.SortFunc(deps, func(a, b string) int {
+		return slices.Index(container.beanSort, a) - slices.Index(container.beanSort, b)
+	})
+	return deps
+}
 
+// findCycle 在依赖图中仅沿 include 为 true 的节点做 DFS，发现环则返回环路径。
+func (container *di) findCycle(deps map[string][]string, include func(name string) bool) error {
 	const (
 		white = 0 // 未访问
 		gray  = 1 // 正在访问（在当前 DFS 栈中）
@@ -91,6 +268,9 @@ func (container *di) checkCircularDependency() error {
 		color[name] = gray
 		path = append(path, name)
 		for _, depName := range deps[name] {
+			if !include(depName) {
+				continue
+			}
 			switch color[depName] {
 			case gray:
 				// 命中环：截取从 depName 开始到当前的路径，并闭合
@@ -117,8 +297,8 @@ func (container *di) checkCircularDependency() error {
 	}
 
 	for _, name := range container.beanSort {
-		// RegisterBean 注册的实例无 definition，跳过
-		if _, ok := container.beanDefinitionMap[name]; !ok {
+		// 没有依赖的 bean（含 RegisterBean 注册的实例）不会是环的起点，跳过
+		if len(deps[name]) == 0 || !include(name) {
 			continue
 		}
 		if color[name] == white {
@@ -133,29 +313,43 @@ func (container *di) checkCircularDependency() error {
 
 // resolveDepNames 将一条 aware 信息解析为具体的 beanName 列表。
 // 命名依赖：直接用 aware 名称。
-// 无名称的接口依赖：按类型匹配所有实现。
+// 无名称的接口依赖：按类型匹配所有实现（已有实例与尚未实例化的定义）。
+// slice/map 批量注入：按元素类型匹配所有实现。
+// 声明了限定标签的依赖：按类型匹配后只保留具备全部标签的。
 //
-// 仅在 checkCircularDependency（Load 启动期独占）内调用，直接读 map 不走 findBeanByName，
-// 避免重入读锁。
+// 直接读 map、不走 findBeanByName（不加锁）：调用方都在 Load 的 goroutine 中、并发回调开始之前执行——
+// 依赖检测与实例化排序（buildGraph）、生命周期排序与阶段检查（lifecycleDeps），以及 initializedParallel 启动 worker 之前划分任务，
+// 此时没有其他 goroutine 写入 beanSort 与各 map。
 func (container *di) resolveDepNames(a aware, ownerName string) []string {
-	if a.Name != "" {
+	if a.Name != "" && len(a.Qualifiers) == 0 {
 		return []string{a.Name}
 	}
-	if a.IsInterface {
+	matchType := a.Type
+	if a.IsSlice || a.IsMap {
+		matchType = a.ElemType
+	}
+	if a.IsInterface || a.IsSlice || a.IsMap || len(a.Qualifiers) > 0 {
 		var names []string
 		for _, depName := range container.beanSort {
 			if depName == ownerName {
 				continue
 			}
-			// 直接读 map（调用方处于启动期独占，无需加锁）
 			bean, ok := container.beanMap[depName]
 			if !ok {
 				bean, ok = container.prototypeMap[depName]
 			}
-			if ok && bean != nil && reflect.TypeOf(bean).AssignableTo(a.Type) {
+			if ok && bean != nil && container.exposedType(depName, bean).AssignableTo(matchType) {
+				names = append(names, depName)
+			} else if def, isDef := container.beanDefinitionMap[depName]; !ok && isDef && def.instanceType().AssignableTo(matchType) {
+				// 尚未实例化的定义（检测在实例化之前执行）按实例类型匹配
 				names = append(names, depName)
 			}
 		}
+		if len(a.Qualifiers) > 0 {
+			names = slices.DeleteFunc(names, func(depName string) bool {
+				return len(container.filterQualified([]BeanWithName{{Name: depName, Bean: container.beanMap[depName]}}, a.Name, a.Qualifiers)) == 0
+			})
+		}
 		return names
 	}
 	return nil
@@ -0,0 +1,271 @@
+package di
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// decorator Decorate/DecorateNamed 注册的装饰函数
+type decorator struct {
+	beanName string             // DecorateNamed 指定的 beanName；为空时按类型匹配
+	target   reflect.Type       // 被装饰的类型：第一个入参与返回值的类型
+	fn       reflect.Value      // 装饰函数
+	args     []reflect.Type     // 入参类型列表（下标 0 为被装饰的 bean）
+	params   map[int]definition // 入参下标:参数结构体（嵌入 di.In）的字段定义
+}
+
+// Decorate 注册装饰函数，包装所有按类型匹配的 bean：fn 形如 func(T, deps...) T 或 func(T, deps...) (T, error)，
+// 第一个入参为被装饰的 bean（T 为指针或接口类型，按类型查找规则匹配），其余入参同 ProvideFunc 注入，
+// 返回值替换原 bean。多个装饰函数按注册顺序依次应用。必须在 Load 前调用。
+func (container *di) Decorate(fn any) DI {
+	return container.DecorateNamed("", fn)
+}
+
+// DecorateNamed 注册只作用于 beanName 的装饰函数，规则同 Decorate。beanName 为空时同 Decorate。
+func (container *di) DecorateNamed(beanName string, fn any) DI {
+	if container.loaded.Load() {
+		container.log.Fatal(fmt.Errorf("%w", ErrLoaded))
+		return container
+	}
+	d, err := container.newDecorator(beanName, fn)
+	if err != nil {
+		container.log.Fatal(err)
+		return container
+	}
+	container.decorators = append(container.decorators, d)
+	container.log.Info(fmt.Sprintf("register decorator %s for %s", factoryName(d.fn), d.describe()))
+	return container
+}
+
+// newDecorator 校验装饰函数签名并解析入参
+func (container *di) newDecorator(beanName string, fn any) (decorator, error) {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func {
+		return decorator{}, fmt.Errorf("%w: Decorate expects a function, got %T", ErrDefinition, fn)
+	}
+	ft := fv.Type()
+	if ft.NumIn() == 0 {
+		return decorator{}, fmt.Errorf("%w: decorator %s must take the decorated bean as first argument", ErrDefinition, ft.String())
+	}
+	target := ft.In(0)
+	if target.Kind() != reflect.Pointer && target.Kind() != reflect.Interface {
+		return decorator{}, fmt.Errorf("%w: decorator %s must decorate a pointer or interface, got %s", ErrDefinition, ft.String(), target.String())
+	}
+	switch {
+	case ft.NumOut() == 1 && ft.Out(0) == target:
+	case ft.NumOut() == 2 && ft.Out(0) == target && ft.Out(1) == errorType:
+	default:
+		return decorator{}, fmt.Errorf("%w: decorator %s must return %s or (%s, error)", ErrDefinition, ft.String(), target.String(), target.String())
+	}
+	args := make([]reflect.Type, ft.NumIn())
+	for i := range args {
+		args[i] = ft.In(i)
+	}
+	params, err := container.paramDefinitions(beanName, ft, nil)
+	if err != nil {
+		return decorator{}, err
+	}
+	return decorator{beanName: beanName, target: target, fn: fv, args: args, params: params}, nil
+}
+
+// matches 判断装饰器是否作用于 beanName；exposed 为该 bean 参与按类型查找的类型
+func (d decorator) matches(beanName string, exposed reflect.Type) bool {
+	if d.beanName != "" {
+		return d.beanName == beanName
+	}
+	return exposed.AssignableTo(d.target)
+}
+
+// describe 返回装饰目标的描述，用于日志
+func (d decorator) describe() string {
+	if d.beanName != "" {
+		return d.beanName
+	}
+	return d.target.String()
+}
+
+// decoratorsOf 返回作用于 beanName 的装饰器（按注册顺序）
+func (container *di) decoratorsOf(beanName string, exposed reflect.Type) []decorator {
+	return slices.DeleteFunc(slices.Clone(container.decorators), func(d decorator) bool {
+		return !d.matches(beanName, exposed)
+	})
+}
+
+// prepareCompletion 在 Load 实例化之前标记 Load 时创建的单例与直接注册的实例中待完成的 bean：
+// 有匹配的装饰器，或注册了后置处理器时由定义创建的单例。
+// 待完成的 bean 被首次取用时才完成注入、后置处理与装饰（completeBean），保证依赖方拿到替换后的实例。
+func (container *di) prepareCompletion() {
+	if len(container.decorators) == 0 && len(container.postProcessors) == 0 {
+		return
+	}
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	for _, name := range container.beanSort {
+		if bean, ok := container.beanMap[name]; ok {
+			// 直接注册的实例不经过后置处理器
+			if len(container.decoratorsOf(name, container.exposedType(name, bean))) > 0 {
+				container.pending[name] = true
+			}
+		} else if def, ok := container.beanDefinitionMap[name]; ok && def.scope == ScopeSingleton && !def.lazyInit {
+			if len(container.postProcessors) > 0 || len(container.decoratorsOf(name, def.instanceType())) > 0 {
+				container.pending[name] = true
+			}
+		}
+	}
+	for _, d := range container.decorators {
+		if d.beanName == "" {
+			continue
+		}
+		if _, isBean := container.beanMap[d.beanName]; !isBean {
+			if _, isDef := container.beanDefinitionMap[d.beanName]; !isDef {
+				container.log.Warn(fmt.Sprintf("decorator %s: bean %s not found", factoryName(d.fn), d.beanName))
+			}
+		}
+	}
+}
+
+// completionAwares 返回待完成单例在交给依赖方之前需要的依赖：字段注入与装饰函数的入参（未经 prepareCompletion 标记的返回 nil）
+func (container *di) completionAwares(def definition) []aware {
+	if !container.pending[def.Name] {
+		return nil
+	}
+	awares := slices.Collect(maps.Values(def.awareMap))
+	for _, d := range container.decoratorsOf(def.Name, def.instanceType()) {
+		awares = append(awares, argAwares(d.args, 1, nil, d.params)...)
+	}
+	return awares
+}
+
+// decorationAwares 同 completionAwares，但只计入被装饰的单例：装饰函数需要完成注入的实例，字段成环时无法构造；
+// 仅经后置处理的单例成环时以原始实例提前注入（见 completeBean）
+func (container *di) decorationAwares(def definition) []aware {
+	if len(container.decoratorsOf(def.Name, def.instanceType())) == 0 {
+		return nil
+	}
+	return container.completionAwares(def)
+}
+
+// pendingCompletion 判断 beanName 是否为 Load 期间尚未完成（后置处理、装饰）的单例
+func (container *di) pendingCompletion(beanName string) bool {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+	return container.pending[beanName]
+}
+
+// completeBean 完成待完成单例：注入依赖（PreInitialize → 注入 → 后置处理器与 AfterPropertiesSet）后依次应用装饰器，
+// 以替换后的实例放入 beanMap。被依赖方首次取用或 processBeans 遍历到时调用，每个 bean 只完成一次。
+// 完成过程中再次需要自身（经由依赖成环）时：被装饰的 bean 报 ErrCircularDependency；
+// 仅经后置处理的 bean 以原始实例提前注入，若随后被后置处理器替换则报 ErrCircularDependency。
+func (container *di) completeBean(beanName string) (any, bool) {
+	container.mu.Lock()
+	if !container.pending[beanName] {
+		bean, ok := container.beanMap[beanName]
+		container.mu.Unlock()
+		return bean, ok
+	}
+	if container.completing[beanName] {
+		prototype, isPrototype := container.prototypeMap[beanName]
+		if isPrototype && len(container.decoratorsOf(beanName, container.exposedType(beanName, prototype))) == 0 {
+			container.earlyRefs[beanName] = true
+			container.mu.Unlock()
+			return prototype, true
+		}
+		container.mu.Unlock()
+		container.fail(beanName, "", fmt.Errorf("%w: %s is required while being decorated", ErrCircularDependency, beanName))
+		return nil, false
+	}
+	container.completing[beanName] = true
+	prototype, isPrototype := container.prototypeMap[beanName]
+	original, isBean := container.beanMap[beanName]
+	def := container.beanDefinitionMap[beanName]
+	container.mu.Unlock()
+
+	bean, replaced := original, false
+	if isPrototype {
+		container.log.Info(fmt.Sprintf("initialize bean %s(%T)", beanName, prototype))
+		original = prototype
+		bean, replaced = container.processBean(prototype, def, prototype)
+	} else if !isBean {
+		// 实例化失败（已上报）
+		original, bean = nil, nil
+	}
+	if bean != nil {
+		var decorated bool
+		bean, decorated = container.decorateBean(beanName, bean, original)
+		replaced = replaced || decorated
+	}
+
+	container.mu.Lock()
+	delete(container.pending, beanName)
+	delete(container.completing, beanName)
+	earlyRef := container.earlyRefs[beanName]
+	delete(container.earlyRefs, beanName)
+	if bean == nil {
+		container.mu.Unlock()
+		return nil, false
+	}
+	container.beanMap[beanName] = bean
+	if replaced {
+		container.originals[beanName] = original
+	}
+	container.mu.Unlock()
+	if earlyRef && replaced {
+		container.fail(beanName, "", fmt.Errorf("%w: %s was injected into a circular reference before post processors replaced it",
+			ErrCircularDependency, beanName))
+	}
+	return bean, true
+}
+
+// decorateBean 按注册顺序依次应用作用于 beanName 的装饰器，返回替换后的实例，replaced 表示是否有装饰器返回了另一个实例
+// （装饰器可以返回值类型，不比较实例值）；入参中的原型实例登记到 owner 名下。失败时返回 nil（错误已通过 fail 上报）。
+func (container *di) decorateBean(beanName string, bean any, owner any) (_ any, replaced bool) {
+	container.mu.RLock()
+	exposed := container.exposedType(beanName, bean)
+	container.mu.RUnlock()
+	for _, d := range container.decoratorsOf(beanName, exposed) {
+		name := factoryName(d.fn)
+		if !reflect.TypeOf(bean).AssignableTo(d.target) {
+			container.fail(beanName, "", fmt.Errorf("%w: decorator %s expects %s, got %s(%T)",
+				ErrBean, name, d.target.String(), beanName, bean))
+			return nil, false
+		}
+		args := make([]reflect.Value, len(d.args))
+		args[0] = reflect.ValueOf(bean)
+		if !container.resolveFuncArgs(beanName, args, d.args, nil, d.params, owner) {
+			return nil, false
+		}
+		results := d.fn.Call(args)
+		if len(results) == 2 {
+			if err, _ := results[1].Interface().(error); err != nil {
+				container.fail(beanName, "", fmt.Errorf("%w: decorator %s failed for %s: %w", ErrBean, name, beanName, err))
+				return nil, false
+			}
+		}
+		if results[0].IsNil() {
+			container.fail(beanName, "", fmt.Errorf("%w: decorator %s returned nil for %s", ErrBean, name, beanName))
+			return nil, false
+		}
+		replacement := results[0].Interface()
+		if !reflect.TypeOf(replacement).AssignableTo(exposed) {
+			container.fail(beanName, "", fmt.Errorf("%w: decorator %s returned %T, not assignable to %s of %s",
+				ErrBean, name, replacement, exposed.String(), beanName))
+			return nil, false
+		}
+		container.log.Info(fmt.Sprintf("decorate bean %s with %s", beanName, name))
+		replaced = replaced || !sameInstance(replacement, bean)
+		bean = replacement
+	}
+	return bean, replaced
+}
+
+// originalOf 返回被装饰 bean 的原始实例（生命周期回调与 cleanup 作用于原始实例）；未被装饰时返回 bean 本身
+func (container *di) originalOf(beanName string, bean any) any {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+	if original, ok := container.originals[beanName]; ok {
+		return original
+	}
+	return bean
+}
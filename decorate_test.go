@@ -0,0 +1,159 @@
+package di
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type decStorage interface{ Get(key string) string }
+
+type decFileStorage struct{ _ int }
+
+func (*decFileStorage) Get(key string) string { return "file:" + key }
+func (*decFileStorage) Initialized()          { testRecorder.add("init-file") }
+func (*decFileStorage) Destroy()              { testRecorder.add("destroy-file") }
+
+type decPrefix struct{ value string }
+
+type decWrapped struct {
+	inner decStorage
+	tag   string
+}
+
+func (w *decWrapped) Get(key string) string { return w.tag + "(" + w.inner.Get(key) + ")" }
+
+type decConsumer struct {
+	Storage decStorage `aware:""`
+}
+
+func withCache(s decStorage) decStorage { return &decWrapped{inner: s, tag: "cache"} }
+
+func withMetrics(s decStorage, p *decPrefix) decStorage {
+	return &decWrapped{inner: s, tag: p.value}
+}
+
+// TestDecorate_Chain 装饰函数按注册顺序应用，依赖方（含先注册的工厂）只拿到装饰后的实例
+func TestDecorate_Chain(t *testing.T) {
+	testRecorder.reset()
+	c := New()
+	var fromFactory string
+	c.ProvideNamedFunc("factoryConsumer", func(s decStorage) *decConsumer {
+		fromFactory = s.Get("k")
+		return &decConsumer{}
+	})
+	c.ProvideNamedBean("fieldConsumer", decConsumer{})
+	c.ProvideFunc(func() decStorage { return &decFileStorage{} })
+	c.RegisterBean(&decPrefix{value: "metrics"})
+	c.Decorate(withCache)
+	c.Decorate(withMetrics)
+	c.Load()
+
+	want := "metrics(cache(file:k))"
+	if fromFactory != want {
+		t.Fatalf("factory want %q, got %q", want, fromFactory)
+	}
+	consumer, _ := c.GetBean("fieldConsumer")
+	if got := consumer.(*decConsumer).Storage.Get("k"); got != want {
+		t.Fatalf("field want %q, got %q", want, got)
+	}
+	if got := MustGet[decStorage](c).Get("k"); got != want {
+		t.Fatalf("GetByType want %q, got %q", want, got)
+	}
+	desc, _ := c.DescribeBean("decStorage")
+	if len(desc.Decorators) != 2 || !strings.HasSuffix(desc.Decorators[0], "withCache") || !strings.HasSuffix(desc.Decorators[1], "withMetrics") {
+		t.Fatalf("want decorators in DescribeBean, got %v", desc.Decorators)
+	}
+
+	// 生命周期回调作用于原始实例
+	c.destroyBeans()
+	if got := strings.Join(testRecorder.events, ","); got != "init-file,destroy-file" {
+		t.Fatalf("want lifecycle on original bean, got %s", got)
+	}
+}
+
+// decSliceStorage 含 slice 的值类型（不可比较）
+type decSliceStorage struct{ items []string }
+
+func (s decSliceStorage) Get(key string) string { return strings.Join(s.items, "|") + ":" + key }
+
+func withSliceValue(s decStorage) decStorage {
+	return decSliceStorage{items: []string{s.Get("inner")}}
+}
+
+// TestDecorate_ValueType 装饰器返回不可比较的值类型时正常替换（不比较实例值），生命周期仍作用于原始实例
+func TestDecorate_ValueType(t *testing.T) {
+	testRecorder.reset()
+	c := New()
+	c.ProvideFunc(func() decStorage { return &decFileStorage{} })
+	c.ProvideNamedBean("fieldConsumer", decConsumer{})
+	c.Decorate(withSliceValue)
+	c.Decorate(withSliceValue)
+	if err := c.LoadE(); err != nil {
+		t.Fatal(err)
+	}
+	consumer, _ := c.GetBean("fieldConsumer")
+	if got := consumer.(*decConsumer).Storage.Get("k"); got != "file:inner:inner:k" {
+		t.Fatalf("want value-type decoration injected, got %q", got)
+	}
+	c.destroyBeans()
+	if got := strings.Join(testRecorder.events, ","); got != "init-file,destroy-file" {
+		t.Fatalf("want lifecycle on original bean, got %s", got)
+	}
+}
+
+type decCounter struct{ n int }
+
+// TestDecorate_Named DecorateNamed 只作用于指定 bean；原型作用域的每个实例都被装饰
+func TestDecorate_Named(t *testing.T) {
+	c := New()
+	c.ProvideNamedFunc("a", func() *decCounter { return &decCounter{n: 1} })
+	c.ProvideNamedFunc("b", func() *decCounter { return &decCounter{n: 1} })
+	c.ProvideNamedFunc("p", func() *decCounter { return &decCounter{n: 1} }, WithScope(ScopePrototype))
+	c.DecorateNamed("b", func(d *decCounter) *decCounter { return &decCounter{n: d.n + 10} })
+	c.DecorateNamed("p", func(d *decCounter) *decCounter { return &decCounter{n: d.n + 100} })
+	c.Load()
+
+	a, _ := c.GetBean("a")
+	b, _ := c.GetBean("b")
+	p, _ := c.GetBean("p")
+	if a.(*decCounter).n != 1 || b.(*decCounter).n != 11 || p.(*decCounter).n != 101 {
+		t.Fatalf("want 1/11/101, got %d/%d/%d", a.(*decCounter).n, b.(*decCounter).n, p.(*decCounter).n)
+	}
+}
+
+var errDecorate = errors.New("decorate failed")
+
+type decCycleA struct {
+	B *decCycleB `aware:""`
+}
+
+type decCycleB struct {
+	A *decCycleA `aware:""`
+}
+
+// TestDecorate_Errors 装饰函数返回 error、签名非法、被装饰的 bean 之间成环
+func TestDecorate_Errors(t *testing.T) {
+	c := New()
+	c.ProvideFunc(func() decStorage { return &decFileStorage{} })
+	c.Decorate(func(s decStorage) (decStorage, error) { return nil, errDecorate })
+	if err := c.LoadE(); !errors.Is(err, ErrBean) || !errors.Is(err, errDecorate) {
+		t.Fatalf("want ErrBean wrapping decorator error, got %v", err)
+	}
+
+	c = New()
+	c.Provide(decCycleA{})
+	c.Provide(decCycleB{})
+	c.Decorate(func(a *decCycleA) *decCycleA { return a })
+	c.Decorate(func(b *decCycleB) *decCycleB { return b })
+	if err := c.LoadE(); !errors.Is(err, ErrCircularDependency) {
+		t.Fatalf("want ErrCircularDependency, got %v", err)
+	}
+
+	defer func() {
+		if r, _ := recover().(error); !errors.Is(r, ErrDefinition) {
+			t.Fatalf("want ErrDefinition, got %v", r)
+		}
+	}()
+	New().Decorate(func(s decStorage) *decCounter { return nil })
+}
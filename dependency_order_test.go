@@ -0,0 +1,88 @@
+package di
+
+import (
+	"strings"
+	"testing"
+)
+
+type doHandler struct {
+	Service *doService `aware:""`
+}
+
+type doService struct{ repo *doRepo }
+
+type doRepo struct {
+	Config *doConfig `aware:""`
+}
+
+type doConfig struct{ _ int }
+
+func (*doHandler) BeanConstruct()      { testRecorder.add("construct:handler") }
+func (*doHandler) AfterPropertiesSet() { testRecorder.add("props:handler") }
+func (*doHandler) Initialized()        { testRecorder.add("init:handler") }
+func (*doHandler) Destroy()            { testRecorder.add("destroy:handler") }
+func (*doService) AfterPropertiesSet() { testRecorder.add("props:service") }
+func (*doService) Initialized()        { testRecorder.add("init:service") }
+func (*doService) Destroy()            { testRecorder.add("destroy:service") }
+func (*doRepo) BeanConstruct()         { testRecorder.add("construct:repo") }
+func (*doRepo) AfterPropertiesSet()    { testRecorder.add("props:repo") }
+func (*doRepo) Initialized()           { testRecorder.add("init:repo") }
+func (*doRepo) Destroy()               { testRecorder.add("destroy:repo") }
+func (*doConfig) Initialized()         { testRecorder.add("init:config") }
+func (*doConfig) Destroy()             { testRecorder.add("destroy:config") }
+
+func newDOContainer() *di {
+	c := New()
+	c.Provide(doHandler{})
+	c.ProvideFunc(func(repo *doRepo) *doService { return &doService{repo: repo} })
+	c.Provide(doRepo{})
+	c.RegisterBean(&doConfig{})
+	return c
+}
+
+func recorded(prefix string) string {
+	var events []string
+	for _, e := range testRecorder.events {
+		if strings.HasPrefix(e, prefix) {
+			events = append(events, strings.TrimPrefix(e, prefix))
+		}
+	}
+	return strings.Join(events, ",")
+}
+
+// TestDependencyOrder 生命周期按依赖拓扑顺序触发（字段、工厂入参、直接注册的实例），销毁严格倒序
+func TestDependencyOrder(t *testing.T) {
+	testRecorder.reset()
+	c := newDOContainer()
+	c.WithDependencyOrder(true)
+	c.Load()
+	c.destroyBeans()
+
+	if got := recorded("construct:"); got != "repo,handler" {
+		t.Fatalf("want construct in dependency order, got %s", got)
+	}
+	if got := recorded("props:"); got != "repo,service,handler" {
+		t.Fatalf("want AfterPropertiesSet in dependency order, got %s", got)
+	}
+	if got := recorded("init:"); got != "config,repo,service,handler" {
+		t.Fatalf("want Initialized in dependency order, got %s", got)
+	}
+	if got := recorded("destroy:"); got != "handler,service,repo,config" {
+		t.Fatalf("want destroy in reverse dependency order, got %s", got)
+	}
+}
+
+// TestDependencyOrder_Default 默认按注册顺序
+func TestDependencyOrder_Default(t *testing.T) {
+	testRecorder.reset()
+	c := newDOContainer()
+	c.Load()
+	c.destroyBeans()
+
+	if got := recorded("init:"); got != "handler,service,repo,config" {
+		t.Fatalf("want Initialized in registration order, got %s", got)
+	}
+	if got := recorded("destroy:"); got != "config,repo,service,handler" {
+		t.Fatalf("want destroy in reverse registration order, got %s", got)
+	}
+}
@@ -0,0 +1,100 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// BeanDependsOn 可被 bean 实现，声明必须先于自身初始化的 bean 名称（无需持有其引用，如数据库迁移先于仓储）。
+// 声明的 bean 参与生命周期顺序、循环依赖检测与 GetBeanDependencies；不存在时 Load 失败。
+// 注册选项 DependsOn 追加的名称与接口声明的合并。
+type BeanDependsOn interface {
+	DependsOn() []string
+}
+
+// DependsOn 声明 bean 依赖的 beanName（与 BeanDependsOn 接口声明的合并）
+func DependsOn(beanNames ...string) BeanOption {
+	return func(def *definition) {
+		def.dependsOn = append(def.dependsOn, beanNames...)
+	}
+}
+
+// declaredDependsOn 读取实例类型实现的 BeanDependsOn 接口声明的 beanName
+func declaredDependsOn(instanceType reflect.Type) []string {
+	if d, ok := zeroInstance(instanceType).(BeanDependsOn); ok {
+		return slices.Clone(d.DependsOn())
+	}
+	return nil
+}
+
+// dependsOnAwares 将显式声明的依赖转为按名称的依赖信息，与字段、入参依赖一起参与依赖图
+func (def definition) dependsOnAwares() []aware {
+	awares := make([]aware, 0, len(def.dependsOn))
+	for _, name := range def.dependsOn {
+		awares = append(awares, aware{Name: name})
+	}
+	return awares
+}
+
+// dependsOnOf 返回 bean 显式声明的依赖：有定义的取定义上的值，直接注册的实例取其（原始实例的）BeanDependsOn 接口。
+func (container *di) dependsOnOf(beanName string) []string {
+	if def, ok := container.beanDefinitionMap[beanName]; ok {
+		return def.dependsOn
+	}
+	if bean, ok := container.beanMap[beanName]; ok {
+		if d, ok := container.originalOf(beanName, bean).(BeanDependsOn); ok {
+			return d.DependsOn()
+		}
+	}
+	return nil
+}
+
+// checkDependsOn 检查显式声明的依赖是否存在（本容器或父容器），在条件评估之后执行，
+// 被条件移除的 bean 视为不存在。返回合并错误（每个缺失依赖一个 *BeanError，包装 ErrDefinition）。
+// 依赖都存在但成环时，返回包装 ErrDefinition 与 ErrCircularDependency、含环路径的错误。
+func (container *di) checkDependsOn() error {
+	var errs []error
+	for _, name := range container.beanSort {
+		for _, depName := range container.dependsOnOf(name) {
+			if !container.hasBeanName(depName) {
+				errs = append(errs, &BeanError{Bean: name, Err: fmt.Errorf("%w: %s depends on %s, notfound", ErrDefinition, name, depName)})
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return loadFailed(errs)
+	}
+	// 显式依赖成环时无法满足任何初始化顺序，无论是否开启 WithCircularCheck 都检测
+	if err := container.findCycle(container.dependsOnGraph(), func(string) bool { return true }); err != nil {
+		return fmt.Errorf("%w: DependsOn %w", ErrDefinition, err)
+	}
+	return nil
+}
+
+// dependsOnGraph 收集每个 bean（含直接注册的实例）显式声明的、本容器内的依赖（父容器的 bean 不会依赖子容器，不会成环）
+func (container *di) dependsOnGraph() map[string][]string {
+	deps := make(map[string][]string, len(container.beanSort))
+	for _, name := range container.beanSort {
+		for _, depName := range container.dependsOnOf(name) {
+			_, isBean := container.beanMap[depName]
+			_, isDef := container.beanDefinitionMap[depName]
+			if (isBean || isDef) && !slices.Contains(deps[name], depName) {
+				deps[name] = append(deps[name], depName)
+			}
+		}
+	}
+	return deps
+}
+
+// hasBeanName 判断本容器或父容器是否注册了名为 beanName 的 bean（实例或定义）
+func (container *di) hasBeanName(beanName string) bool {
+	container.mu.RLock()
+	_, isBean := container.beanMap[beanName]
+	_, isDef := container.beanDefinitionMap[beanName]
+	container.mu.RUnlock()
+	if isBean || isDef {
+		return true
+	}
+	return container.parent != nil && container.parent.hasBeanName(beanName)
+}
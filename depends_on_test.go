@@ -0,0 +1,108 @@
+package di
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type dpRepo struct {
+	Config *dpConfig `aware:""`
+}
+
+type dpConfig struct{ _ int }
+
+type dpMigration struct{ _ int }
+
+// dpCache 以接口声明依赖
+type dpCache struct{ _ int }
+
+func (*dpCache) DependsOn() []string { return []string{"dpMigration"} }
+
+func (*dpRepo) AfterPropertiesSet()      { testRecorder.add("props:repo") }
+func (*dpRepo) Initialized()             { testRecorder.add("init:repo") }
+func (*dpMigration) AfterPropertiesSet() { testRecorder.add("props:migration") }
+func (*dpMigration) Initialized()        { testRecorder.add("init:migration") }
+func (*dpCache) Initialized()            { testRecorder.add("init:cache") }
+func (*dpCache) Destroy()                { testRecorder.add("destroy:cache") }
+func (*dpMigration) Destroy()            { testRecorder.add("destroy:migration") }
+func (*dpRepo) Destroy()                 { testRecorder.add("destroy:repo") }
+
+// TestDependsOn 显式依赖先初始化、后销毁（默认注册顺序下同样生效），并计入 GetBeanDependencies
+func TestDependsOn(t *testing.T) {
+	testRecorder.reset()
+	c := New()
+	c.Provide(dpRepo{}, DependsOn("dpMigration", "dpConfig"))
+	c.RegisterBean(&dpCache{})
+	c.Provide(dpConfig{})
+	c.Provide(dpMigration{})
+	c.Load()
+	c.destroyBeans()
+
+	if got := recorded("props:"); got != "migration,repo" {
+		t.Fatalf("want AfterPropertiesSet after dependency, got %s", got)
+	}
+	if got := recorded("init:"); got != "migration,repo,cache" {
+		t.Fatalf("want Initialized after dependency, got %s", got)
+	}
+	if got := recorded("destroy:"); got != "cache,repo,migration" {
+		t.Fatalf("want destroy before dependency, got %s", got)
+	}
+	deps, _ := c.GetBeanDependencies("dpRepo")
+	if !slices.Equal(deps, []string{"dpConfig", "dpMigration"}) {
+		t.Fatalf("want declared dependencies, got %v", deps)
+	}
+	if desc, _ := c.DescribeBean("dpRepo"); !slices.Equal(desc.DependsOn, []string{"dpMigration", "dpConfig"}) {
+		t.Fatalf("want DescribeBean DependsOn, got %v", desc.DependsOn)
+	}
+}
+
+// TestDependsOn_Missing 声明的依赖不存在时 Load 失败，补充注册后可重试
+func TestDependsOn_Missing(t *testing.T) {
+	c := New()
+	c.Provide(dpRepo{}, DependsOn("dpMigration"))
+	c.Provide(dpConfig{})
+	err := c.LoadE()
+	var beanErr *BeanError
+	if !errors.Is(err, ErrLoadFailed) || !errors.Is(err, ErrDefinition) || !errors.As(err, &beanErr) || beanErr.Bean != "dpRepo" {
+		t.Fatalf("want missing dependency error, got %v", err)
+	}
+	c.Provide(dpMigration{})
+	if err := c.LoadE(); err != nil {
+		t.Fatalf("want retry after registering dependency, got %v", err)
+	}
+
+	child := c.NewChild()
+	child.Provide(dpCache{})
+	if err := child.LoadE(); err != nil {
+		t.Fatalf("want dependency resolved from parent, got %v", err)
+	}
+}
+
+// TestDependsOn_Cycle 显式依赖成环时 Load 失败（无需开启 WithCircularCheck），错误包含环路径
+func TestDependsOn_Cycle(t *testing.T) {
+	c := New()
+	c.WithCircularCheck(true)
+	c.Provide(dpMigration{}, DependsOn("dpCache"))
+	c.Provide(dpCache{})
+	if err := c.LoadE(); !errors.Is(err, ErrCircularDependency) {
+		t.Fatalf("want circular dependency, got %v", err)
+	}
+
+	c = New()
+	c.Provide(dpMigration{}, DependsOn("dpCache"))
+	c.Provide(dpCache{})
+	err := c.LoadE()
+	if !errors.Is(err, ErrDefinition) || !errors.Is(err, ErrCircularDependency) || !strings.Contains(err.Error(), "dpMigration -> dpCache -> dpMigration") {
+		t.Fatalf("want DependsOn cycle without WithCircularCheck, got %v", err)
+	}
+
+	// 直接注册的实例以 BeanDependsOn 接口参与成环
+	c = New()
+	c.Provide(dpMigration{}, DependsOn("dpCache"))
+	c.RegisterBean(&dpCache{})
+	if err := c.LoadE(); !errors.Is(err, ErrDefinition) {
+		t.Fatalf("want DependsOn cycle through a registered instance, got %v", err)
+	}
+}
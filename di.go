@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"slices"
 	"sync"
+	"sync/atomic"
 
 	"github.com/cheivin/di/van"
 )
@@ -19,14 +20,44 @@ type (
 		beanDefinitionMap map[string]definition // Name:bean定义
 		prototypeMap      map[string]any        // Name:初始化的bean
 		beanMap           map[string]any        // Name:bean实例
-		loaded            bool
+		loaded            atomic.Bool           // Load 开始后为 true（失败可还原）；查找在 Load 期间可能并发读取
 		unsafe            bool
 		valueStore        ValueStore
 		beanSort          []string // 注册顺序（beanName）
 		ctx               context.Context
 		mu                sync.RWMutex // 保护 beanDefinitionMap/prototypeMap/beanMap/beanSort
 		selector          BeanSelector
-		circularCheck     bool // 是否在 Load 时检测循环依赖（默认关闭，指针循环依赖可正常注入）
+		circularCheck     bool                              // 是否在 Load 时检测循环依赖（默认关闭，指针循环依赖可正常注入）
+		dependencyOrder   bool                              // 生命周期按依赖拓扑顺序（WithDependencyOrder），默认按注册顺序
+		order             []string                          // Load 时确定的生命周期顺序（beanName）；销毁按其倒序
+		workers           int                               // 并行触发 Initialized 的 worker 数（WithParallelInit），<= 1 时串行
+		startTimeout      lifecycleTimeout                  // Startable.Start 的超时（WithStartTimeout）
+		stopTimeout       lifecycleTimeout                  // Stoppable.Stop 的超时（WithStopTimeout）
+		started           []BeanWithName                    // 已启动的 bean（按启动顺序），停止时倒序
+		loadErr           error                             // LoadE 失败后的合并错误；非 nil 表示容器处于失败状态
+		errMu             sync.Mutex                        // 保护 collecting/loadErrs
+		collecting        bool                              // LoadE 期间为 true：装配错误收集而非 Fatal
+		loadErrs          []error                           // LoadE 期间收集的装配错误
+		dependents        map[any][]BeanWithName            // owner 实例:为其创建的原型作用域实例（按创建顺序）；nil key 归容器所有
+		parent            *di                               // 父容器：本容器未找到的 bean 回退到父容器查找
+		scopes            map[context.Context]*requestScope // 活跃的请求作用域视图
+		lazyInits         map[string]*sync.Once             // 延迟单例的创建状态（WithLazyInit）
+		initStarted       bool                              // Load 已开始触发 Initialized；此前创建的延迟单例推迟到该阶段触发
+		deferredInits     map[string]bool                   // 在 Load 触发 Initialized 之前被创建、推迟触发 Initialized 的延迟单例
+		modules           map[string]*Module                // 已安装的模块（按名称去重）
+		installing        string                            // 正在安装的模块名称（Install 期间）
+		beanModules       map[string]string                 // beanName:注册它的模块名称
+		profiles          []string                          // WithProfiles 设置的激活 profile；nil 表示未设置
+		profileProperties map[string]map[string]any         // profile:专属配置项
+		cleanups          map[string][]cleanupEntry         // beanName:各实例的工厂 cleanup
+		factoryFields     bool                              // 对所有返回结构体指针的工厂做字段注入（WithFactoryFieldInjection）
+		overriding        bool                              // 重复注册同名 bean 时替换先注册的（AllowBeanOverriding）
+		decorators        []decorator                       // 装饰函数（按注册顺序）
+		postProcessors    []BeanPostProcessor               // bean 后置处理器（按注册顺序）
+		pending           map[string]bool                   // Load 期间尚未完成（后置处理、装饰）的单例
+		completing        map[string]bool                   // 正在完成的单例（检测重入）
+		earlyRefs         map[string]bool                   // 完成前因字段循环依赖被提前注入原始实例的单例
+		originals         map[string]any                    // beanName:被装饰或被后置处理器替换的单例的原始实例
 	}
 )
 
@@ -70,9 +101,46 @@ func New() *di {
 		beanSort:          []string{},
 		ctx:               context.Background(),
 		selector:          LastRegistered{},
+		dependents:        map[any][]BeanWithName{},
+		scopes:            map[context.Context]*requestScope{},
+		lazyInits:         map[string]*sync.Once{},
+		deferredInits:     map[string]bool{},
+		modules:           map[string]*Module{},
+		beanModules:       map[string]string{},
+		profileProperties: map[string]map[string]any{},
+		cleanups:          map[string][]cleanupEntry{},
+		pending:           map[string]bool{},
+		completing:        map[string]bool{},
+		earlyRefs:         map[string]bool{},
+		originals:         map[string]any{},
 	}
 }
 
+// NewChild 创建以当前容器为父容器的子容器。
+//
+// 子容器的 bean、配置与生命周期都是本地的：Load/Serve 只处理子容器自己注册的 bean；
+// aware 注入、GetBean、GetByType、GetByTypeAll、HasBeanType 在本地找不到时回退到父容器（逐级向上）。
+// 配置按层叠加：子容器的 SetProperty 等写入本地层，读取时本地未设置的 key 回退到父容器。
+// 子容器继承父容器的日志、不安全模式、选择策略、循环依赖检测、工厂字段注入、同名覆盖、生命周期顺序、并行初始化与启停超时设置，可单独修改。
+// 装饰函数与后置处理器不继承：它们属于父容器的注册，只作用于父容器创建的 bean（含其请求作用域视图），
+// 子容器需要时自行注册。父容器须先 Load，子容器才能 Load。
+func (container *di) NewChild() DI {
+	child := New()
+	child.parent = container
+	child.log = container.log
+	child.unsafe = container.unsafe
+	child.selector = container.selector
+	child.circularCheck = container.circularCheck
+	child.factoryFields = container.factoryFields
+	child.overriding = container.overriding
+	child.dependencyOrder = container.dependencyOrder
+	child.workers = container.workers
+	child.startTimeout = container.startTimeout
+	child.stopTimeout = container.stopTimeout
+	child.valueStore = &layeredValueStore{ValueStore: van.New(), parent: container}
+	return child
+}
+
 // UnsafeMode 开启不安全模式，允许通过 unsafe.Pointer 注入未导出（私有）字段。
 // 开启后影响所有 value/aware 注入，容器会打印 warn 日志。
 func (container *di) UnsafeMode(open bool) DI {
@@ -116,6 +184,16 @@ func (container *di) WithCircularCheck(enable bool) DI {
 	return container
 }
 
+// WithDependencyOrder 开启/关闭按依赖顺序触发生命周期，默认关闭（按注册顺序）。
+// 开启后 Load 时按依赖图（aware 字段、工厂入参、装饰函数入参）做拓扑排序：
+// 被依赖的 bean 先于依赖方实例化（BeanConstruct）、完成注入（AfterPropertiesSet）与触发 Initialized，
+// 销毁严格按该顺序的倒序。无依赖关系的 bean 之间保持注册顺序；指针循环依赖的环内按注册顺序。
+// 必须在 Load 前调用。
+func (container *di) WithDependencyOrder(enable bool) DI {
+	container.dependencyOrder = enable
+	return container
+}
+
 // WithBeanSelector 设置接口多实现时的选择策略。
 // 传入 nil 则恢复默认的 LastRegistered。必须在 Load 前调用。
 func (container *di) WithBeanSelector(s BeanSelector) DI {
@@ -148,28 +226,54 @@ func (container *di) RegisterNamedBean(beanName string, bean any) DI {
 	}
 	container.mu.Lock()
 	defer container.mu.Unlock()
+	// 同名的后备定义（ConditionalOnMissingBean）被实例替换
+	if def, exist := container.beanDefinitionMap[beanName]; exist && def.fallback() {
+		container.log.Info(fmt.Sprintf("fallback bean %s(%s) replaced by registered bean", beanName, def.Type.String()))
+		delete(container.beanDefinitionMap, beanName)
+		container.beanSort = slices.DeleteFunc(container.beanSort, func(name string) bool { return name == beanName })
+	}
+	replaced, err := container.overrideRegistration(beanName, fmt.Sprintf("%T", bean))
+	if err != nil {
+		container.log.Fatal(err)
+		return container
+	}
 	if _, exist := container.beanMap[beanName]; exist {
 		container.log.Fatal(fmt.Errorf("%w: bean %s already exists", ErrBean, beanName))
 		return container
 	}
 	container.beanMap[beanName] = bean
 	// 加入队列
-	container.beanSort = append(container.beanSort, beanName)
+	if !replaced {
+		container.beanSort = append(container.beanSort, beanName)
+		// Load 之后注册的实例排在生命周期顺序末尾（最先销毁）
+		if container.loaded.Load() {
+			container.order = append(container.order, beanName)
+		}
+	}
+	container.recordModule(beanName)
 	container.log.Info(fmt.Sprintf("register bean with name: %s", beanName))
 	return container
 }
 
 // Provide 注册结构体原型（值类型），容器在 Load 时反射实例化为指针并注入依赖。
-// beanName 按 [parseBeanType] 规则推断。
-func (container *di) Provide(prototype any) DI {
-	container.ProvideNamedBean("", prototype)
+// beanName 按 [parseBeanType] 规则推断。opts 为注册选项（如 WithScope）。
+func (container *di) Provide(prototype any, opts ...BeanOption) DI {
+	container.ProvideNamedBean("", prototype, opts...)
 	return container
 }
 
 // ProvideFunc 注册工厂函数，容器按入参类型注入依赖，用返回值作为 bean。
-// fn 必须是 func(...) (...)，且只有一个返回值（指针类型）。
-func (container *di) ProvideFunc(fn any) DI {
-	if container.loaded {
+// fn 的返回值为 T、(T, error) 或 (T, func(), error)，T 为指针或接口类型。opts 为注册选项（如 WithScope）。
+// beanName 按返回类型推断（见 [ProvideNamedFunc]）。
+func (container *di) ProvideFunc(fn any, opts ...BeanOption) DI {
+	return container.ProvideNamedFunc("", fn, opts...)
+}
+
+// ProvideNamedFunc 以指定名称注册工厂函数，同一类型的多个工厂可以不同名称共存。
+// beanName 为空时按返回类型推断：优先返回类型实现的 BeanName 接口，否则取类型名首字母小写。
+// 返回接口类型时 bean 只以该接口参与按类型查找，实现类型不对外发布。
+func (container *di) ProvideNamedFunc(beanName string, fn any, opts ...BeanOption) DI {
+	if container.loaded.Load() {
 		container.log.Fatal(fmt.Errorf("%w", ErrLoaded))
 		return container
 	}
@@ -179,20 +283,20 @@ func (container *di) ProvideFunc(fn any) DI {
 		return container
 	}
 	ft := fv.Type()
-	if ft.NumOut() != 1 {
-		container.log.Fatal(fmt.Errorf("%w: ProvideFunc must return exactly one value, got %d", ErrBean, ft.NumOut()))
+	if err := checkFactoryReturns(ft); err != nil {
+		container.log.Fatal(err)
 		return container
 	}
 	returnType := ft.Out(0)
-	if returnType.Kind() != reflect.Pointer {
-		container.log.Fatal(fmt.Errorf("%w: ProvideFunc must return a pointer, got %s", ErrBean, returnType.String()))
+	if returnType.Kind() != reflect.Pointer && returnType.Kind() != reflect.Interface {
+		container.log.Fatal(fmt.Errorf("%w: ProvideFunc must return a pointer or interface, got %s", ErrBean, returnType.String()))
 		return container
 	}
 	// beanName：优先用返回类型实现 BeanName 接口的值，否则按类型推断
-	beanName := ""
-	tmpBean := reflect.New(returnType.Elem()).Interface()
-	if bn, ok := tmpBean.(BeanName); ok {
-		beanName = bn.BeanName()
+	if beanName == "" {
+		if bn, ok := zeroInstance(returnType).(BeanName); ok {
+			beanName = bn.BeanName()
+		}
 	}
 	if beanName == "" {
 		beanName = GetBeanName(returnType)
@@ -209,9 +313,39 @@ func (container *di) ProvideFunc(fn any) DI {
 		valueMap:    map[string]aware{},
 		factory:     fv,
 		factoryArgs: args,
+		scope:       declaredScope(returnType),
+		qualifiers:  declaredQualifiers(returnType),
+		order:       declaredOrder(returnType),
+		phase:       declaredPhase(returnType),
+		dependsOn:   declaredDependsOn(returnType),
+	}
+	def.applyOptions(opts)
+	for index := range def.argQualifiers {
+		if index < 0 || index >= len(args) {
+			container.log.Fatal(fmt.Errorf("%w: ArgQualifier index %d out of range for %s", ErrDefinition, index, ft.String()))
+			return container
+		}
+	}
+	params, err := container.paramDefinitions(beanName, ft, def.argQualifiers)
+	if err != nil {
+		container.log.Fatal(err)
+		return container
+	}
+	def.params = params
+	if def.injectFields && !isStructPointer(returnType) {
+		container.log.Fatal(fmt.Errorf("%w: WithFieldInjection requires a struct pointer return, got %s", ErrDefinition, returnType.String()))
+		return container
 	}
 	container.mu.Lock()
 	defer container.mu.Unlock()
+	if container.resolveFallback(def) {
+		return container
+	}
+	replaced, err := container.overrideRegistration(beanName, def.Type.String())
+	if err != nil {
+		container.log.Fatal(err)
+		return container
+	}
 	if _, exist := container.beanMap[beanName]; exist {
 		container.log.Fatal(fmt.Errorf("%w: bean %s already exists", ErrBean, beanName))
 		return container
@@ -221,15 +355,18 @@ func (container *di) ProvideFunc(fn any) DI {
 		return container
 	}
 	container.beanDefinitionMap[beanName] = def
-	container.beanSort = append(container.beanSort, beanName)
-	container.log.Info(fmt.Sprintf("provide bean(factory) with name: %s", beanName))
+	if !replaced {
+		container.beanSort = append(container.beanSort, beanName)
+	}
+	container.recordModule(beanName)
+	container.log.Info(fmt.Sprintf("provide %s bean(factory) with name: %s", def.scope, beanName))
 	return container
 }
 
 // ProvideNamedBean 以指定名称注册结构体原型。
 // beanName 为空时按 [parseBeanType] 推断。Load 后调用会 Fatal。
-func (container *di) ProvideNamedBean(beanName string, beanType any) DI {
-	if container.loaded {
+func (container *di) ProvideNamedBean(beanName string, beanType any, opts ...BeanOption) DI {
+	if container.loaded.Load() {
 		container.log.Fatal(fmt.Errorf("%w", ErrLoaded))
 		return container
 	}
@@ -241,9 +378,24 @@ func (container *di) ProvideNamedBean(beanName string, beanType any) DI {
 	}
 	// newDefinition 含反射与日志，在锁外执行避免长持有
 	def := container.newDefinition(beanName, prototype)
+	def.scope = declaredScope(def.instanceType())
+	def.qualifiers = declaredQualifiers(def.instanceType())
+	def.order = declaredOrder(def.instanceType())
+	def.phase = declaredPhase(def.instanceType())
+	def.dependsOn = declaredDependsOn(def.instanceType())
+	def.applyOptions(opts)
 
 	container.mu.Lock()
 	defer container.mu.Unlock()
+	// 同名的后备定义（ConditionalOnMissingBean）静默让位
+	if container.resolveFallback(def) {
+		return container
+	}
+	replaced, err := container.overrideRegistration(beanName, def.Type.String())
+	if err != nil {
+		container.log.Fatal(err)
+		return container
+	}
 	// 检查bean重复
 	if _, exist := container.beanMap[beanName]; exist {
 		container.log.Fatal(fmt.Errorf("%w: bean %s already exists", ErrBean, beanName))
@@ -256,62 +408,83 @@ func (container *di) ProvideNamedBean(beanName string, beanType any) DI {
 	}
 	container.beanDefinitionMap[beanName] = def
 	// 加入队列
-	container.beanSort = append(container.beanSort, beanName)
-	container.log.Info(fmt.Sprintf("provide bean with name: %s", beanName))
+	if !replaced {
+		container.beanSort = append(container.beanSort, beanName)
+	}
+	container.recordModule(beanName)
+	container.log.Info(fmt.Sprintf("provide %s bean with name: %s", def.scope, beanName))
 	return container
 }
 
 // GetBean 按名称获取 bean 实例。线程安全（读锁）。
+// 原型作用域的 bean 每次调用都返回一个新实例（归容器所有，容器销毁时 Destroy）。
+// 本容器未找到时回退到父容器查找。
 func (container *di) GetBean(beanName string) (bean any, ok bool) {
 	container.mu.RLock()
-	defer container.mu.RUnlock()
 	bean, ok = container.beanMap[beanName]
+	def, isDef := container.beanDefinitionMap[beanName]
+	container.mu.RUnlock()
+	if !ok && isDef && def.deferred() && container.loaded.Load() {
+		bean = container.newDeferredBean(def, nil)
+		ok = bean != nil
+	}
+	if !ok && !isDef && container.parent != nil {
+		return container.parent.findBeanByName(beanName, container)
+	}
 	return
 }
 
 // getAllByType 按类型查找 bean。beanType 接受值类型或指针类型（如 T{} 或 (*T)(nil)）。
 // limitOne 为 true 时找到第一个即返回（GetByType 使用）。
-// 按注册顺序（beanSort）返回。线程安全（读锁）。
-func (container *di) getAllByType(beanType any, limitOne bool) (beans []BeanWithName) {
+// 按注册顺序（beanSort）查找；返回全部时按排序值稳定排序。原型作用域的 bean 每次创建新实例，登记到 owner 名下（nil 归容器所有）。
+// 本容器没有匹配项时回退到父容器查找。
+// 线程安全（读锁，实例创建在锁外）。
+func (container *di) getAllByType(beanType any, limitOne bool, owner any) (beans []BeanWithName) {
 	// 空/nil 参数直接返回空
-	t := reflect.TypeOf(beanType)
-	if t == nil {
+	typeValue := matchType(beanType)
+	if typeValue == nil {
 		return
 	}
-	var typeValue reflect.Type
-	if t.Kind() == reflect.Pointer {
-		typeValue = t.Elem()
-		if typeValue.Kind() == reflect.Struct {
-			typeValue = reflect.PtrTo(typeValue)
-		}
-	} else {
-		typeValue = reflect.PtrTo(t)
-	}
+	var prototypes []definition
 	container.mu.RLock()
-	defer container.mu.RUnlock()
 	// 按注册顺序（beanSort）遍历，保证返回顺序确定（遍历 map 的顺序是随机的）
 	for _, name := range container.beanSort {
-		bean, ok := container.beanMap[name]
-		if !ok {
-			continue
-		}
-		if reflect.TypeOf(bean).AssignableTo(typeValue) {
-			beans = append(beans, BeanWithName{
-				Name: name,
-				Bean: bean,
-			})
-			if limitOne {
-				return
+		if bean, ok := container.beanMap[name]; ok {
+			if container.exposedType(name, bean).AssignableTo(typeValue) {
+				beans = append(beans, BeanWithName{Name: name, Bean: bean})
+				prototypes = append(prototypes, definition{})
 			}
+		} else if def, ok := container.beanDefinitionMap[name]; ok && def.deferred() && container.loaded.Load() {
+			if def.instanceType().AssignableTo(typeValue) {
+				beans = append(beans, BeanWithName{Name: name})
+				prototypes = append(prototypes, def)
+			}
+		}
+		if limitOne && len(beans) > 0 {
+			break
 		}
 	}
-	return
+	container.mu.RUnlock()
+	if len(beans) == 0 && container.parent != nil {
+		return container.parent.getAllByType(beanType, limitOne, container.parentOwner(owner))
+	}
+	// 原型作用域/延迟创建的单例：锁外创建实例（生命周期回调可能反向访问容器）
+	for i := range beans {
+		if beans[i].Bean == nil {
+			beans[i].Bean = container.newDeferredBean(prototypes[i], owner)
+		}
+	}
+	beans = slices.DeleteFunc(beans, func(b BeanWithName) bool { return b.Bean == nil })
+	if !limitOne {
+		container.sortByOrder(beans)
+	}
+	return beans
 }
 
 // GetByType 按类型获取单个 bean（返回第一个匹配项）。
 // beanType 可传值类型或指针类型，如 di.GetByType(&UserService{}) 或 di.GetByType((*Service)(nil))。
 func (container *di) GetByType(beanType any) (any, bool) {
-	beans := container.getAllByType(beanType, true)
+	beans := container.getAllByType(beanType, true, nil)
 	if len(beans) == 0 {
 		return nil, false
 	} else {
@@ -319,9 +492,9 @@ func (container *di) GetByType(beanType any) (any, bool) {
 	}
 }
 
-// GetByTypeAll 按类型获取所有匹配的 bean（含名称），按注册顺序返回。
+// GetByTypeAll 按类型获取所有匹配的 bean（含名称），按排序值（Ordered/WithOrder）升序返回，相同时按注册顺序。
 func (container *di) GetByTypeAll(beanType any) (beans []BeanWithName) {
-	return container.getAllByType(beanType, false)
+	return container.getAllByType(beanType, false, nil)
 }
 
 // GetBeanNames 返回所有已注册 bean 的名称（按注册顺序，含工厂 bean）。
@@ -333,37 +506,44 @@ func (container *di) GetBeanNames() []string {
 
 // HasBeanType 判断容器中是否已注册指定类型的 bean（实例/原型/工厂 bean 均可）。
 // beanType 可传值类型或指针类型（如 HasBeanType(User{}) 或 HasBeanType((*User)(nil))）。
-// 线程安全（读锁）。
+// 本容器没有时回退到父容器判断。线程安全（读锁）。
 func (container *di) HasBeanType(beanType any) bool {
-	t := reflect.TypeOf(beanType)
-	if t == nil {
-		return false
+	if container.hasBeanType(beanType) {
+		return true
 	}
-	// 归一化为指针类型（与 getAllByType 一致）：值类型取 *T，指针类型取 T 或 *T，接口类型保持原样
-	var typeValue reflect.Type
-	if t.Kind() == reflect.Pointer {
-		typeValue = t.Elem()
-		if typeValue.Kind() == reflect.Struct {
-			typeValue = reflect.PtrTo(typeValue)
-		}
-	} else {
-		typeValue = reflect.PtrTo(t)
+	return container.parent != nil && container.parent.HasBeanType(beanType)
+}
+
+// hasBeanType 判断本容器是否已注册指定类型的 bean
+func (container *di) hasBeanType(beanType any) bool {
+	return container.hasLocalBeanType(beanType, func(string) bool { return false })
+}
+
+// hasBeanTypeExcept 同 HasBeanType，但忽略 skip 返回 true 的本地 bean（条件评估时排除自身与待评估的后备定义）
+func (container *di) hasBeanTypeExcept(beanType any, skip func(beanName string) bool) bool {
+	if container.hasLocalBeanType(beanType, skip) {
+		return true
+	}
+	return container.parent != nil && container.parent.HasBeanType(beanType)
+}
+
+func (container *di) hasLocalBeanType(beanType any, skip func(beanName string) bool) bool {
+	// 归一化为指针类型（与 getAllByType 一致）
+	typeValue := matchType(beanType)
+	if typeValue == nil {
+		return false
 	}
 	return withRLock(container, func() bool {
 		// 已实例化的 bean（RegisterBean）
-		for _, bean := range container.beanMap {
-			if reflect.TypeOf(bean).AssignableTo(typeValue) {
+		for name, bean := range container.beanMap {
+			if !skip(name) && container.exposedType(name, bean).AssignableTo(typeValue) {
 				return true
 			}
 		}
 		// 原型/工厂定义（Provide/ProvideFunc）：Load 后实例入 beanMap，这里补 Load 前的查询
-		for _, def := range container.beanDefinitionMap {
-			if def.factory.IsValid() {
-				// 工厂 bean：Type 为返回指针类型，直接比较
-				if def.Type.AssignableTo(typeValue) {
-					return true
-				}
-			} else if reflect.PtrTo(def.Type).AssignableTo(typeValue) {
+		for name, def := range container.beanDefinitionMap {
+			// 工厂 bean：Type 为返回类型（指针或接口）；原型 bean：取 *Type
+			if !skip(name) && def.instanceType().AssignableTo(typeValue) {
 				return true
 			}
 		}
@@ -408,119 +588,288 @@ func (container *di) newBean(def definition) (bean any) {
 	prototype := container.instanceBean(def)
 	// 触发构造方法
 	container.constructBean(def.Name, prototype)
-	// 触发注入 bean
-	bean = container.processBean(prototype, def)
-	// 初始化完成
-	container.initializedBean(def.Name, bean)
+	// 触发注入 bean（其依赖的原型实例归容器所有，避免 owner 引用阻止 GC 触发 finalizer）
+	bean, replaced := container.processBean(prototype, def, nil)
+	// 初始化完成（生命周期回调作用于原始实例）
+	container.initializedBean(def.Name, prototype)
 	// 使用析构函数来完成 bean 的 destroy
-	runtime.SetFinalizer(bean, func(bean any) {
-		container.destroyBean(def.Name, bean)
-	})
+	if !replaced {
+		runtime.SetFinalizer(bean, func(bean any) {
+			container.destroyBean(def.Name, bean)
+		})
+	} else {
+		// 后置处理器替换了实例：随返回的实例回收时销毁原始实例
+		runtime.SetFinalizer(bean, func(any) {
+			container.destroyBean(def.Name, prototype)
+		})
+	}
 	return
 }
 
 // Load 加载容器：实例化所有 bean、注入依赖、触发生命周期回调。
 // 首先做循环依赖检测（失败则 panic ErrCircularDependency，且 loaded 置回 false 允许 recover 后重试）。
-// Load 后再次调用会 panic ErrLoaded。
+// Load 后再次调用会 panic ErrLoaded；容器处于 LoadE 失败状态时 panic 该失败错误。
+// 装配错误在第一次出现时即通过 Log.Fatal 上报（fail-fast），需要完整报告请使用 LoadE。
 func (container *di) Load() {
-	if container.loaded {
-		panic(ErrLoaded)
+	if err := container.load(false); err != nil {
+		panic(err)
 	}
+}
 
-	container.loaded = true
+// LoadE 加载容器，与 Load 流程一致，但不在第一个错误处 panic：
+// 遍历整个容器收集所有装配错误（缺失依赖、value 转换失败、选择策略报错、工厂失败等），
+// 每个错误为 *BeanError，最终以 errors.Join 合并返回（首个元素包装 ErrLoadFailed）。
+//
+// 有错误时：不触发任何 Initialized 回调，已实例化的原型/工厂 bean 从容器中移除
+// （RegisterBean 注册的实例保留），容器进入失败状态，之后 Load/LoadE/Serve/ServeE 均返回该错误。
+// 循环依赖检测失败时直接返回 ErrCircularDependency，容器保持未加载状态，可修正后重试。
+func (container *di) LoadE() error {
+	return container.load(true)
+}
+
+// load 是 Load/LoadE 的公共流程。collect 为 true 时收集装配错误并返回合并错误。
+func (container *di) load(collect bool) error {
+	if container.loadErr != nil {
+		return container.loadErr
+	}
+	// 子容器的依赖回退到父容器的 bean 实例，父容器必须先完成加载
+	if container.parent != nil && !container.parent.loaded.Load() {
+		return fmt.Errorf("%w: parent container must be loaded first", ErrNotLoaded)
+	}
+	if !container.loaded.CompareAndSwap(false, true) {
+		return ErrLoaded
+	}
+	container.mu.Lock()
+	container.initStarted = false
+	container.mu.Unlock()
+	// profile 专属配置先于条件评估写入，ConditionalOnProperty 可以看到
+	container.applyProfileProperties(container.ActiveProfiles())
+	// 注册条件在实例化与依赖检测之前评估，不满足的定义被移除
+	container.evaluateConditions()
+	// 工厂产物的字段定义先于依赖检测解析，字段依赖参与检测
+	if err := container.prepareFactoryFields(); err != nil {
+		container.loaded.Store(false)
+		return err
+	}
+	container.prepareCompletion()
+	// 显式声明的依赖必须存在且不能成环，失败还原 loaded 允许补充注册后重试
+	if err := container.checkDependsOn(); err != nil {
+		container.loaded.Store(false)
+		return err
+	}
 	// 循环依赖检测为 opt-in：默认关闭（指针循环依赖可正常注入）。
 	// 仅当显式 WithCircularCheck(true) 时才检测，失败还原 loaded 允许重试。
 	if container.circularCheck {
 		if err := container.checkCircularDependency(); err != nil {
-			container.loaded = false
-			panic(err)
+			container.loaded.Store(false)
+			return err
 		}
 	}
+	// 按需创建的 bean 之间的环无法满足（原型无限递归创建、延迟单例重入自身创建），始终检测
+	if err := container.checkDeferredCycle(); err != nil {
+		container.loaded.Store(false)
+		return err
+	}
+	// 工厂入参之间的环无法构造，始终检测
+	if err := container.checkFactoryCycle(); err != nil {
+		container.loaded.Store(false)
+		return err
+	}
+	// 依赖位于更后的阶段时，阶段顺序与依赖顺序冲突
+	if err := container.checkPhaseOrder(); err != nil {
+		container.loaded.Store(false)
+		return err
+	}
+	container.order = container.lifecycleOrder()
+	if collect {
+		container.beginCollect()
+	}
 	container.initializeBeans()
 	container.processBeans()
+	if collect {
+		if errs := container.endCollect(); len(errs) > 0 {
+			container.loadErr = loadFailed(errs)
+			container.discardBeans()
+			return container.loadErr
+		}
+	}
 	container.initialized()
+	// 启动失败（已回滚停止）：bean 已触发 Initialized，走完整销毁流程后进入失败状态
+	if err := container.startBeans(); err != nil {
+		container.loadErr = err
+		container.destroyBeans()
+		return err
+	}
+	return nil
+}
 
+// discardBeans 加载失败后移除所有由定义创建的 bean（原型/工厂），仅保留 RegisterBean 注册的实例（撤销装饰）。
+// 这些单例 bean 未触发 Initialized，也不会触发 Destroy，但工厂返回的 cleanup 会倒序执行以释放资源；
+// 装配期间已创建的原型作用域实例走过完整生命周期，倒序销毁。
+func (container *di) discardBeans() {
+	var discarded []BeanWithName
+	container.mu.Lock()
+	for _, name := range container.beanSort {
+		original, decorated := container.originals[name]
+		delete(container.originals, name)
+		if _, isDef := container.beanDefinitionMap[name]; !isDef {
+			// 直接注册的实例保留，撤销装饰
+			if decorated {
+				container.beanMap[name] = original
+			}
+			continue
+		}
+		if decorated {
+			discarded = append(discarded, BeanWithName{Name: name, Bean: original})
+		} else if bean, ok := container.beanMap[name]; ok {
+			discarded = append(discarded, BeanWithName{Name: name, Bean: bean})
+		} else if bean, ok := container.prototypeMap[name]; ok {
+			discarded = append(discarded, BeanWithName{Name: name, Bean: bean})
+		}
+		delete(container.beanMap, name)
+	}
+	clear(container.prototypeMap)
+	clear(container.pending)
+	clear(container.completing)
+	clear(container.earlyRefs)
+	owners := slices.Collect(maps.Keys(container.dependents))
+	container.mu.Unlock()
+	for _, owner := range owners {
+		container.destroyDependents(owner)
+	}
+	for _, b := range slices.Backward(discarded) {
+		container.runCleanup(b.Name, b.Bean)
+	}
 }
 
-// Serve 阻塞等待 ctx 结束，然后倒序销毁所有 bean（触发 Destroy 回调）。
-// 必须在 Load 之后调用，否则 panic ErrNotLoaded。
+// Serve 阻塞等待 ctx 结束，然后倒序停止（Stop）并销毁所有 bean（触发 Destroy 回调）。
+// 必须在 Load 之后调用，否则 panic ErrNotLoaded；容器处于 LoadE 失败状态时 panic 该失败错误。
+// Stop 的错误只打印 warn 日志，需要处理时请使用 ServeE。
 // 通常配合 signal.NotifyContext 监听 SIGINT/SIGTERM 使用。
 func (container *di) Serve(ctx context.Context) {
-	if !container.loaded {
-		panic(ErrNotLoaded)
+	if err := container.ServeE(ctx); err != nil && !errors.Is(err, ErrStop) {
+		panic(err)
+	}
+}
+
+// ServeE 与 Serve 一致，但以返回值代替 panic：
+// 未加载返回 ErrNotLoaded，LoadE 失败状态返回加载失败的错误；
+// 退出时先关闭请求作用域视图，再按启动倒序 Stop，最后销毁，各 bean 的 Stop 错误（包装 ErrStop）以 errors.Join 合并返回。
+func (container *di) ServeE(ctx context.Context) error {
+	if container.loadErr != nil {
+		return container.loadErr
+	}
+	if !container.loaded.Load() {
+		return ErrNotLoaded
 	}
 	var cancel context.CancelFunc
 	container.ctx, cancel = context.WithCancel(ctx)
 	defer cancel()
 	<-ctx.Done()
+	container.destroyScopes()
+	errs := container.stopBeans(ctx)
 	container.destroyBeans()
+	return errors.Join(errs...)
 }
 
 // initializeBeans 初始化bean对象
 func (container *di) initializeBeans() {
-	// 锁内收集 definition 快照，释放锁后再实例化
-	// （工厂 bean 实例化时会反向调 findBeanByName/findBeanByType，持锁会死锁）
-	container.mu.Lock()
-	snapshot := slices.Collect(maps.Values(container.beanDefinitionMap))
-	container.mu.Unlock()
-	// 创建类型的指针对象（instanceBean 含工厂调用/value 注入/日志，必须在锁外）
-	prototypes := make(map[string]any, len(snapshot))
-	for _, def := range snapshot {
-		prototypes[def.Name] = container.instanceBean(def)
-	}
-	container.mu.Lock()
-	maps.Copy(container.prototypeMap, prototypes)
-	container.mu.Unlock()
-	// 根据排序遍历触发BeanConstruct方法（回调可能反向访问容器，必须在锁外）
-	for _, beanName := range container.beanSort {
-		container.mu.RLock()
-		prototype, ok := container.prototypeMap[beanName]
-		container.mu.RUnlock()
-		if ok {
-			container.constructBean(beanName, prototype)
+	// 按实例化顺序逐个创建：工厂入参依赖的定义先于工厂创建。
+	// 实例化在锁外（工厂 bean 实例化时会反向调 findBeanByName/findBeanByType，持锁会死锁），
+	// 每个实例创建后立即放入 prototypeMap，供后续工厂的入参查找
+	for _, def := range container.instantiationOrder() {
+		// 实例化失败（LoadE 收集模式）返回 nil，不放入 prototypeMap
+		if prototype := container.instanceBean(def); prototype != nil {
+			container.mu.Lock()
+			container.prototypeMap[def.Name] = prototype
+			container.mu.Unlock()
+			// 触发BeanConstruct方法（回调可能反向访问容器，必须在锁外）
+			container.constructBean(def.Name, prototype)
 		}
 	}
 }
 
-// processBeans 注入依赖
+// processBeans 按生命周期顺序注入依赖；待完成的 bean 由 completeBean 完成（已被依赖方提前完成的跳过）
 func (container *di) processBeans() {
-	for _, beanName := range container.beanSort {
+	for _, beanName := range container.order {
+		if container.pendingCompletion(beanName) {
+			container.completeBean(beanName)
+			continue
+		}
 		container.mu.RLock()
 		prototype, ok := container.prototypeMap[beanName]
+		_, done := container.beanMap[beanName]
 		def := container.beanDefinitionMap[beanName]
 		container.mu.RUnlock()
-		if !ok {
+		if !ok || done {
 			continue
 		}
 		// 加载为bean
 		container.log.Info(fmt.Sprintf("initialize bean %s(%T)", def.Name, prototype))
 		// processBean 含 PreInitialize/wireBean/AfterPropertiesSet 回调，必须在锁外执行
-		bean := container.processBean(prototype, def)
+		bean, replaced := container.processBean(prototype, def, prototype)
 		container.mu.Lock()
 		container.beanMap[beanName] = bean
+		if replaced {
+			container.originals[beanName] = prototype
+		}
 		container.mu.Unlock()
 	}
 }
 
-// initialized 容器初始化完成
+// initialized 容器初始化完成，按生命周期顺序触发 Initialized（WithParallelInit 时并行，见 initializedParallel）
+// Load 期间被提前创建的延迟单例在此随其他 bean 一起触发。
 func (container *di) initialized() {
-	for _, beanName := range container.beanSort {
+	deferred := container.beginInitialized()
+	if container.workers > 1 {
+		container.initializedParallel(deferred)
+		return
+	}
+	for _, beanName := range container.order {
 		container.mu.RLock()
-		bean := container.beanMap[beanName]
+		bean, ok := container.beanMap[beanName]
+		def := container.beanDefinitionMap[beanName]
 		container.mu.RUnlock()
-		// 回调在锁外
-		container.initializedBean(beanName, bean)
+		// 此后才创建的延迟单例由 lazyInitBean 自行触发 Initialized
+		if ok && (!def.lazyInit || deferred[beanName]) {
+			// 回调在锁外；被装饰的 bean 回调作用于原始实例
+			container.initializedBean(beanName, container.originalOf(beanName, bean))
+		}
 	}
 }
 
-// destroyBeans 按注册倒序销毁 bean：锁内从 beanMap 移除，锁外触发 Destroy 回调。
+// beginInitialized 标记 Load 开始触发 Initialized，返回此前被创建、推迟触发 Initialized 的延迟单例
+func (container *di) beginInitialized() map[string]bool {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	container.initStarted = true
+	deferred := container.deferredInits
+	container.deferredInits = map[string]bool{}
+	return deferred
+}
+
+// destroyBeans 按生命周期顺序的倒序销毁 bean：锁内从 beanMap 移除，锁外触发 Destroy 回调。
+// 先销毁仍然活跃的请求作用域视图；每个 bean 销毁后紧接着倒序销毁为其创建的原型实例；
+// 最后销毁归容器所有的原型实例。
 func (container *di) destroyBeans() {
+	container.destroyScopes()
+	defer func() {
+		container.destroyDependents(nil)
+		if container.parent != nil {
+			container.parent.destroyDependents(container)
+		}
+	}()
 	// 倒序销毁bean
-	for _, beanName := range slices.Backward(container.beanSort) {
+	for _, beanName := range slices.Backward(container.order) {
 		container.mu.Lock()
 		bean, ok := container.beanMap[beanName]
 		if ok {
 			delete(container.beanMap, beanName)
+			// 被装饰的 bean 销毁原始实例
+			if original, decorated := container.originals[beanName]; decorated {
+				bean = original
+				delete(container.originals, beanName)
+			}
 		}
 		container.mu.Unlock()
 		if ok {
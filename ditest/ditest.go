@@ -0,0 +1,242 @@
+// Package ditest 提供容器的测试辅助：创建并加载测试容器、以替身替换 bean、
+// 在测试结束时走完整的销毁流程，并把装配失败（Fatal panic / LoadE 错误）转为 t.Fatalf。
+//
+//	func TestService(t *testing.T) {
+//		c := ditest.New(t,
+//			ditest.Setup(app.Register),
+//			ditest.Replace((*app.Mailer)(nil), &fakeMailer{}),
+//		)
+//		svc := ditest.MustResolve[*app.Service](t, c)
+//		...
+//	}
+package ditest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cheivin/di"
+)
+
+// Option 配置 New 创建的测试容器
+type Option func(h *harness)
+
+// harness New 的配置，按阶段应用：配置项与 profile → Setup → 替换 → Load
+type harness struct {
+	properties map[string]any
+	profiles   []string
+	setups     []func(c di.DI)
+	replaces   []func(c di.DI)
+	noLoad     bool
+	verbose    bool
+}
+
+// Setup 在容器上执行注册（如应用的注册函数），多个 Setup 按传入顺序执行
+func Setup(fn func(c di.DI)) Option {
+	return func(h *harness) {
+		h.setups = append(h.setups, fn)
+	}
+}
+
+// Replace 在所有 Setup 之后以 bean 替换按类型唯一匹配的已注册 bean（见 DI.OverrideType）
+func Replace(beanType any, bean any) Option {
+	return func(h *harness) {
+		h.replaces = append(h.replaces, func(c di.DI) { c.OverrideType(beanType, bean) })
+	}
+}
+
+// ReplaceNamed 在所有 Setup 之后以 bean 替换名为 beanName 的已注册 bean（见 DI.Override）
+func ReplaceNamed(beanName string, bean any) Option {
+	return func(h *harness) {
+		h.replaces = append(h.replaces, func(c di.DI) { c.Override(beanName, bean) })
+	}
+}
+
+// Properties 在 Setup 之前设置配置项（SetPropertyMap）
+func Properties(properties map[string]any) Option {
+	return func(h *harness) {
+		if h.properties == nil {
+			h.properties = map[string]any{}
+		}
+		for k, v := range properties {
+			h.properties[k] = v
+		}
+	}
+}
+
+// Profiles 设置激活的 profile（WithProfiles）
+func Profiles(profiles ...string) Option {
+	return func(h *harness) {
+		h.profiles = profiles
+	}
+}
+
+// NoLoad 不自动加载，由测试自行调用 Load/LoadE（如需断言加载失败）
+func NoLoad() Option {
+	return func(h *harness) {
+		h.noLoad = true
+	}
+}
+
+// Verbose 将容器的 info/debug 日志输出到 t.Log（默认只输出 warn）
+func Verbose() Option {
+	return func(h *harness) {
+		h.verbose = true
+	}
+}
+
+// New 创建测试容器：应用配置、执行 Setup 与替换后调用 LoadE 加载（NoLoad 时跳过），
+// 并注册 t.Cleanup 在测试结束时倒序停止并销毁所有 bean（触发 Stop、Destroy 与工厂 cleanup，Stop 的错误以 t.Errorf 报告）。
+// 注册与加载期间的 Fatal panic 以及 LoadE 返回的装配错误均转为 t.Fatalf，错误信息中列出出错的 bean。
+func New(t testing.TB, opts ...Option) di.DI {
+	t.Helper()
+	h := &harness{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	c := di.New().Log(&testLog{t: t, verbose: h.verbose})
+	if h.verbose {
+		c.DebugMode(true)
+	}
+	guard(t, "setup", func() {
+		if h.profiles != nil {
+			c.WithProfiles(h.profiles...)
+		}
+		if h.properties != nil {
+			c.SetPropertyMap(h.properties)
+		}
+		for _, setup := range h.setups {
+			setup(c)
+		}
+		for _, replace := range h.replaces {
+			replace(c)
+		}
+	})
+	t.Cleanup(func() {
+		// 已取消的 ctx：ServeE 立即走停止与销毁流程；未加载或加载失败时返回错误，无需销毁
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := c.ServeE(ctx); errors.Is(err, di.ErrStop) {
+			t.Errorf("ditest: stop: %s", describe(err))
+		}
+	})
+	if !h.noLoad {
+		var err error
+		guard(t, "load", func() { err = c.LoadE() })
+		if err != nil {
+			t.Fatalf("ditest: load: %s", describe(err))
+		}
+	}
+	return c
+}
+
+// MustResolve 按类型 T 获取 bean（同 di.Get），找不到或创建失败时 t.Fatalf
+func MustResolve[T any](t testing.TB, c di.DI) T {
+	t.Helper()
+	var (
+		bean T
+		err  error
+	)
+	guard(t, "resolve", func() { bean, err = di.Get[T](c) })
+	if err != nil {
+		t.Fatalf("ditest: resolve %s: %s", reflect.TypeFor[T]().String(), describe(err))
+	}
+	return bean
+}
+
+// MustResolveNamed 按名称获取 bean 并断言为 T（同 di.Named），找不到或类型不匹配时 t.Fatalf
+func MustResolveNamed[T any](t testing.TB, c di.DI, beanName string) T {
+	t.Helper()
+	var (
+		bean T
+		err  error
+	)
+	guard(t, "resolve", func() { bean, err = di.Named[T](c, beanName) })
+	if err != nil {
+		t.Fatalf("ditest: resolve %s: %s", beanName, describe(err))
+	}
+	return bean
+}
+
+// guard 执行 fn，将其中 Log.Fatal 抛出的错误 panic 转为 t.Fatalf；其他 panic 原样抛出
+func guard(t testing.TB, stage string, fn func()) {
+	t.Helper()
+	var fatal error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err, ok := r.(error)
+				if !ok {
+					panic(r)
+				}
+				fatal = err
+			}
+		}()
+		fn()
+	}()
+	if fatal != nil {
+		t.Fatalf("ditest: %s: %s", stage, describe(fatal))
+	}
+}
+
+// describe 展开错误：合并错误逐行列出，装配错误标注出错的 bean 与字段
+func describe(err error) string {
+	var lines []string
+	var walk func(err error)
+	walk = func(err error) {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				walk(e)
+			}
+			return
+		}
+		var beanErr *di.BeanError
+		switch {
+		case errors.As(err, &beanErr) && beanErr.Field != "":
+			lines = append(lines, fmt.Sprintf("bean %s (field %s): %s", beanErr.Bean, beanErr.Field, err.Error()))
+		case errors.As(err, &beanErr):
+			lines = append(lines, fmt.Sprintf("bean %s: %s", beanErr.Bean, err.Error()))
+		default:
+			lines = append(lines, err.Error())
+		}
+	}
+	walk(err)
+	return strings.Join(lines, "\n\t")
+}
+
+// testLog 将容器日志输出到 t.Log；Fatal 保持 panic（容器依赖该语义），由 guard 转为 t.Fatalf
+type testLog struct {
+	t       testing.TB
+	verbose bool
+	debug   bool
+}
+
+func (l *testLog) DebugMode(b bool) {
+	l.debug = b
+}
+
+func (l *testLog) Debug(s string) {
+	if l.verbose && l.debug {
+		l.t.Log("[DI-DEBUG] : " + s)
+	}
+}
+
+func (l *testLog) Info(s string) {
+	if l.verbose {
+		l.t.Log("[DI-INFO] : " + s)
+	}
+}
+
+func (l *testLog) Warn(s string) {
+	l.t.Log("[DI-WARN] : " + s)
+}
+
+func (l *testLog) Fatal(err error) {
+	panic(err)
+}
@@ -0,0 +1,123 @@
+package ditest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cheivin/di"
+)
+
+// fakeTB 记录 Fatalf 与 Cleanup，用于断言 harness 的失败行为
+type fakeTB struct {
+	testing.TB
+	fatal    string
+	cleanups []func()
+}
+
+type fatalExit struct{}
+
+func (f *fakeTB) Helper()           {}
+func (f *fakeTB) Log(args ...any)   {}
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.fatal = fmt.Sprintf(format, args...)
+	panic(fatalExit{})
+}
+
+// run 执行 fn，Fatalf 时提前结束；随后按 testing 的顺序倒序执行 Cleanup
+func (f *fakeTB) run(fn func(t testing.TB)) {
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				if _, ok := r.(fatalExit); !ok {
+					panic(r)
+				}
+			}
+		}()
+		fn(f)
+	}()
+	for _, cleanup := range slices.Backward(f.cleanups) {
+		cleanup()
+	}
+}
+
+type mailer interface{ Send(to string) string }
+
+type smtpMailer struct{ _ int }
+
+func (*smtpMailer) Send(to string) string { return "smtp:" + to }
+
+type fakeMailer struct{ _ int }
+
+func (*fakeMailer) Send(to string) string { return "fake:" + to }
+
+type service struct {
+	Mailer    mailer `aware:""`
+	From      string `value:"mail.from"`
+	destroyed *bool
+}
+
+func (s *service) Destroy() { *s.destroyed = true }
+
+type brokenService struct {
+	Missing *smtpMailer `aware:""`
+}
+
+// TestNew 自动加载、按类型替换、测试结束时走销毁流程
+func TestNew(t *testing.T) {
+	destroyed := false
+	f := &fakeTB{}
+	f.run(func(t testing.TB) {
+		c := New(t,
+			Properties(map[string]any{"mail.from": "noreply"}),
+			Setup(func(c di.DI) {
+				c.ProvideNamedFunc("mailer", func() mailer { return &smtpMailer{} })
+				c.ProvideFunc(func() *service { return &service{destroyed: &destroyed} }, di.WithFieldInjection())
+			}),
+			Replace((*mailer)(nil), &fakeMailer{}),
+		)
+		svc := MustResolve[*service](t, c)
+		if got := svc.Mailer.Send("a"); got != "fake:a" || svc.From != "noreply" {
+			t.Errorf("want replaced mailer and properties, got %q %q", got, svc.From)
+		}
+		if m := MustResolveNamed[mailer](t, c, "mailer"); m.Send("b") != "fake:b" {
+			t.Errorf("want replaced mailer by name")
+		}
+	})
+	if f.fatal != "" {
+		t.Fatalf("unexpected fatal: %s", f.fatal)
+	}
+	if !destroyed {
+		t.Fatal("want beans destroyed on cleanup")
+	}
+}
+
+// TestNew_Fatal 注册期 Fatal 与加载错误转为 Fatalf，并标出出错的 bean
+func TestNew_Fatal(t *testing.T) {
+	f := &fakeTB{}
+	f.run(func(t testing.TB) {
+		New(t, Setup(func(c di.DI) { c.RegisterBean(smtpMailer{}) }))
+	})
+	if !strings.HasPrefix(f.fatal, "ditest: setup:") || !strings.Contains(f.fatal, "pointer") {
+		t.Fatalf("want setup fatal, got %q", f.fatal)
+	}
+
+	f = &fakeTB{}
+	f.run(func(t testing.TB) {
+		New(t, Setup(func(c di.DI) { c.Provide(brokenService{}) }))
+	})
+	if !strings.HasPrefix(f.fatal, "ditest: load:") || !strings.Contains(f.fatal, "bean brokenService (field Missing)") {
+		t.Fatalf("want load fatal naming the bean, got %q", f.fatal)
+	}
+
+	f = &fakeTB{}
+	f.run(func(t testing.TB) {
+		MustResolve[mailer](t, New(t))
+	})
+	if !strings.HasPrefix(f.fatal, "ditest: resolve ditest.mailer:") {
+		t.Fatalf("want resolve fatal, got %q", f.fatal)
+	}
+}
@@ -0,0 +1,128 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"slices"
+)
+
+var (
+	errorType   = reflect.TypeFor[error]()
+	cleanupType = reflect.TypeFor[func()]()
+)
+
+// checkFactoryReturns 校验 ProvideFunc 工厂的返回值：
+// T、(T, error) 或 (T, func(), error)，func() 为销毁时执行的 cleanup。
+func checkFactoryReturns(ft reflect.Type) error {
+	switch ft.NumOut() {
+	case 1:
+	case 2:
+		if ft.Out(1) != errorType {
+			return fmt.Errorf("%w: ProvideFunc second return value must be error, got %s", ErrBean, ft.Out(1).String())
+		}
+	case 3:
+		if ft.Out(1) != cleanupType || ft.Out(2) != errorType {
+			return fmt.Errorf("%w: ProvideFunc must return (T, func(), error), got %s", ErrBean, ft.String())
+		}
+	default:
+		return fmt.Errorf("%w: ProvideFunc must return exactly one value, or (T, error) / (T, func(), error), got %d", ErrBean, ft.NumOut())
+	}
+	return nil
+}
+
+// exposedType 返回 bean 参与按类型查找时的类型（调用方持锁）：
+// 返回接口类型的工厂产物只以该接口发布，其余为实例的动态类型
+func (container *di) exposedType(beanName string, bean any) reflect.Type {
+	if def, ok := container.beanDefinitionMap[beanName]; ok && def.factory.IsValid() && def.Type.Kind() == reflect.Interface {
+		return def.Type
+	}
+	return reflect.TypeOf(bean)
+}
+
+// factoryName 返回工厂函数名（用于错误信息）
+func factoryName(fn reflect.Value) string {
+	if f := runtime.FuncForPC(fn.Pointer()); f != nil {
+		return f.Name()
+	}
+	return fn.Type().String()
+}
+
+// WithFactoryFieldInjection 对所有返回结构体指针的工厂开启/关闭字段注入（同 WithFieldInjection 选项）。
+// 默认关闭：工厂产物视为已构造完成，返回结构体上的 aware/value 标签被忽略。必须在 Load 前调用。
+func (container *di) WithFactoryFieldInjection(enable bool) DI {
+	container.factoryFields = enable
+	return container
+}
+
+// isStructPointer 判断类型是否为结构体指针
+func isStructPointer(t reflect.Type) bool {
+	return t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct
+}
+
+// prepareFactoryFields 为开启字段注入的工厂定义解析返回结构体的 aware/value 字段，在依赖检测之前执行，
+// 使字段依赖参与循环依赖检测。标签非法时返回 ErrDefinition。
+func (container *di) prepareFactoryFields() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok && errors.Is(e, ErrDefinition) {
+				err = e
+				return
+			}
+			panic(r)
+		}
+	}()
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	for name, def := range container.beanDefinitionMap {
+		if !def.factory.IsValid() || !isStructPointer(def.Type) || !(def.injectFields || container.factoryFields) {
+			continue
+		}
+		fields := container.newDefinition(name, def.Type.Elem())
+		def.injectFields = true
+		def.awareMap, def.valueMap = fields.awareMap, fields.valueMap
+		container.beanDefinitionMap[name] = def
+	}
+	return nil
+}
+
+// cleanupEntry 工厂返回的 cleanup 及其所属实例（原型作用域与 NewBean 的同名 bean 可有多个实例）
+type cleanupEntry struct {
+	bean    any
+	cleanup func()
+}
+
+// addCleanup 登记工厂返回的 cleanup，bean 销毁时执行
+func (container *di) addCleanup(beanName string, bean any, cleanup func()) {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	container.cleanups[beanName] = append(container.cleanups[beanName], cleanupEntry{bean: bean, cleanup: cleanup})
+}
+
+// takeCleanup 从登记中摘除 beanName 下属于 bean 的 cleanup
+func (container *di) takeCleanup(beanName string, bean any) (func(), bool) {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	entries := container.cleanups[beanName]
+	// 工厂产物均为指针（见 instanceBean），按实例身份比较
+	i := slices.IndexFunc(entries, func(e cleanupEntry) bool { return e.bean == bean })
+	if i < 0 {
+		return nil, false
+	}
+	cleanup := entries[i].cleanup
+	if entries = slices.Delete(entries, i, i+1); len(entries) == 0 {
+		delete(container.cleanups, beanName)
+	} else {
+		container.cleanups[beanName] = entries
+	}
+	return cleanup, true
+}
+
+// runCleanup 执行并移除 bean 的 cleanup（锁内摘除，锁外执行）
+func (container *di) runCleanup(beanName string, bean any) {
+	if cleanup, ok := container.takeCleanup(beanName, bean); ok {
+		container.log.Debug(fmt.Sprintf("call factory cleanup for %s(%T)", beanName, bean))
+		cleanup()
+	}
+}
@@ -0,0 +1,81 @@
+package di
+
+import (
+	"errors"
+	"testing"
+)
+
+type ffDB struct{ _ int }
+
+type ffClient struct {
+	addr    string
+	DB      *ffDB  `aware:""`
+	Timeout int    `value:"client.timeout"`
+	Name    string `value:"client.name"`
+	wiredDB bool
+}
+
+func (c *ffClient) AfterPropertiesSet() { c.wiredDB = c.DB != nil }
+
+func newFFClient() *ffClient { return &ffClient{addr: "localhost", Name: "default"} }
+
+// TestFactoryFields_Option WithFieldInjection 在 AfterPropertiesSet 之前注入工厂产物的字段
+func TestFactoryFields_Option(t *testing.T) {
+	c := New()
+	c.SetProperty("client.timeout", 30)
+	c.Provide(ffDB{})
+	c.ProvideFunc(newFFClient, WithFieldInjection())
+	c.Load()
+
+	client := MustGet[*ffClient](c)
+	if client.addr != "localhost" || client.Name != "default" {
+		t.Fatalf("want factory values kept, got %+v", client)
+	}
+	if client.Timeout != 30 {
+		t.Fatalf("want value injected, got %d", client.Timeout)
+	}
+	if !client.wiredDB {
+		t.Fatal("want aware field injected before AfterPropertiesSet")
+	}
+	desc, _ := c.DescribeBean("ffClient")
+	if len(desc.Dependencies) != 1 || len(desc.Values) != 2 {
+		t.Fatalf("want field definitions described, got %+v", desc)
+	}
+}
+
+// TestFactoryFields_Default 默认不注入；WithFactoryFieldInjection 对所有工厂生效
+func TestFactoryFields_Default(t *testing.T) {
+	c := New()
+	c.Provide(ffDB{})
+	c.ProvideFunc(newFFClient)
+	c.Load()
+	if client := MustGet[*ffClient](c); client.DB != nil {
+		t.Fatal("factory fields should not be injected by default")
+	}
+
+	c = New()
+	c.WithFactoryFieldInjection(true)
+	c.Provide(ffDB{})
+	c.ProvideFunc(newFFClient)
+	c.Load()
+	if client := MustGet[*ffClient](c); client.DB == nil {
+		t.Fatal("want fields injected when enabled globally")
+	}
+
+	c = New()
+	c.ProvideFunc(newFFClient, WithFieldInjection())
+	var beanErr *BeanError
+	if err := c.LoadE(); !errors.As(err, &beanErr) || beanErr.Field != "DB" {
+		t.Fatalf("want missing field error, got %v", err)
+	}
+}
+
+// TestFactoryFields_InvalidReturn 返回非结构体指针时 WithFieldInjection 报 ErrDefinition
+func TestFactoryFields_InvalidReturn(t *testing.T) {
+	defer func() {
+		if r, _ := recover().(error); !errors.Is(r, ErrDefinition) {
+			t.Fatalf("want ErrDefinition, got %v", r)
+		}
+	}()
+	New().ProvideFunc(func() pnfStorage { return nil }, WithFieldInjection())
+}
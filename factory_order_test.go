@@ -0,0 +1,81 @@
+package di
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type foConfig struct{ dsn string }
+
+type foDB struct{ cfg *foConfig }
+
+type foRepo struct{ db *foDB }
+
+type foService struct {
+	repo     *foRepo
+	handlers []foHandler
+}
+
+type foHandler interface{ Name() string }
+
+type foHandlerImpl struct{ name string }
+
+func (h *foHandlerImpl) Name() string { return h.name }
+
+type foServiceParams struct {
+	In
+	Repo     *foRepo     `aware:""`
+	Handlers []foHandler `aware:""`
+}
+
+// TestFactoryOrder_Chain 工厂入参由其他工厂产出时按依赖顺序实例化，与注册顺序无关
+func TestFactoryOrder_Chain(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		c := New()
+		c.ProvideFunc(func(p foServiceParams) *foService {
+			return &foService{repo: p.Repo, handlers: p.Handlers}
+		})
+		c.ProvideFunc(func(db *foDB) *foRepo { return &foRepo{db: db} })
+		c.ProvideNamedFunc("h1", func() foHandler { return &foHandlerImpl{name: "h1"} })
+		c.ProvideFunc(func(cfg *foConfig) *foDB { return &foDB{cfg: cfg} })
+		c.ProvideNamedFunc("h2", func() foHandler { return &foHandlerImpl{name: "h2"} })
+		c.ProvideFunc(func() *foConfig { return &foConfig{dsn: "mem"} })
+		c.Load()
+
+		svc := MustGet[*foService](c)
+		if svc.repo.db.cfg.dsn != "mem" {
+			t.Fatalf("want factory chain resolved, got %+v", svc.repo.db.cfg)
+		}
+		if len(svc.handlers) != 2 || svc.handlers[0].Name() != "h1" || svc.handlers[1].Name() != "h2" {
+			t.Fatalf("want handlers from factories in registration order, got %v", svc.handlers)
+		}
+	}
+}
+
+// TestFactoryOrder_ThroughLazy 经由延迟单例间接依赖的工厂先实例化
+func TestFactoryOrder_ThroughLazy(t *testing.T) {
+	c := New()
+	c.ProvideFunc(func(repo *foRepo) *foService { return &foService{repo: repo} })
+	c.ProvideFunc(func(db *foDB) *foRepo { return &foRepo{db: db} }, WithLazyInit())
+	c.ProvideFunc(func() *foDB { return &foDB{cfg: &foConfig{dsn: "lazy"}} })
+	c.Load()
+
+	if svc := MustGet[*foService](c); svc.repo.db.cfg.dsn != "lazy" {
+		t.Fatalf("want lazy chain resolved, got %+v", svc.repo.db.cfg)
+	}
+}
+
+// TestFactoryOrder_Cycle 工厂入参之间成环时报 ErrCircularDependency
+func TestFactoryOrder_Cycle(t *testing.T) {
+	c := New()
+	c.ProvideFunc(func(repo *foRepo) *foDB { return &foDB{} })
+	c.ProvideFunc(func(db *foDB) *foRepo { return &foRepo{db: db} })
+	err := c.LoadE()
+	if !errors.Is(err, ErrCircularDependency) {
+		t.Fatalf("want ErrCircularDependency, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "foDB -> foRepo -> foDB") || !strings.Contains(err.Error(), "factory") {
+		t.Fatalf("want factory cycle chain in error, got %q", err.Error())
+	}
+}
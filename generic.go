@@ -0,0 +1,101 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// lookupType 校验并返回泛型参数 T 对应的查找类型：只接受指针或接口类型
+// （容器中的 bean 都是指针，值类型 T 无法返回容器内的实例）。
+func lookupType[T any]() (reflect.Type, error) {
+	t := reflect.TypeFor[T]()
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return t, nil
+	default:
+		return nil, fmt.Errorf("%w: type parameter must be a pointer or interface, got %s", ErrDefinition, t.String())
+	}
+}
+
+// Get 按类型 T 获取一个 bean，T 为指针或接口类型（如 di.Get[*UserService](c)、di.Get[Storage](c)）。
+// 多个候选时与 aware 接口注入一致，交由容器的 BeanSelector 选择；找不到或选择失败返回错误（包装 ErrBean）。
+// 原型作用域的 bean 每次返回新实例。Load 之前调用返回 ErrNotLoaded（不会提前创建实例、触发生命周期回调）。
+func Get[T any](c DI) (bean T, err error) {
+	t, err := lookupType[T]()
+	if err != nil {
+		return bean, err
+	}
+	container, ok := c.(*di)
+	if !ok {
+		// 非内置容器实现：退化为 GetByType（取第一个匹配项）
+		found, ok := c.GetByType((*T)(nil))
+		if !ok {
+			return bean, fmt.Errorf("%w: bean of type %s notfound", ErrBean, t.String())
+		}
+		return found.(T), nil
+	}
+	if !container.loaded.Load() {
+		return bean, fmt.Errorf("%w: Get[%s] requires a loaded container", ErrNotLoaded, t.String())
+	}
+	candidates := container.findBeanByType(t)
+	if len(candidates) == 0 {
+		return bean, fmt.Errorf("%w: bean of type %s notfound", ErrBean, t.String())
+	}
+	idx, err := container.selector.Select(candidates, t)
+	if err != nil {
+		return bean, fmt.Errorf("%w: select failed for %s, %s", ErrBean, t.String(), err.Error())
+	}
+	found := container.materialize(candidates[idx], nil)
+	if found == nil {
+		return bean, fmt.Errorf("%w: bean %s of type %s create failed", ErrBean, candidates[idx].Name, t.String())
+	}
+	return found.(T), nil
+}
+
+// MustGet 同 Get，出错时 panic（错误可用 errors.Is 判断）。
+func MustGet[T any](c DI) T {
+	bean, err := Get[T](c)
+	if err != nil {
+		panic(err)
+	}
+	return bean
+}
+
+// GetAll 按类型 T 获取所有匹配的 bean，顺序同 GetByTypeAll。没有匹配项时返回空切片。
+func GetAll[T any](c DI) ([]T, error) {
+	if _, err := lookupType[T](); err != nil {
+		return nil, err
+	}
+	found := c.GetByTypeAll((*T)(nil))
+	beans := make([]T, 0, len(found))
+	for _, b := range found {
+		beans = append(beans, b.Bean.(T))
+	}
+	return beans, nil
+}
+
+// Named 按名称获取 bean 并断言为 T。找不到或类型不匹配返回错误（包装 ErrBean）。
+func Named[T any](c DI, name string) (bean T, err error) {
+	found, ok := c.GetBean(name)
+	if !ok {
+		return bean, fmt.Errorf("%w: %s notfound", ErrBean, name)
+	}
+	bean, ok = found.(T)
+	if !ok {
+		return bean, fmt.Errorf("%w: %s(%T) not match type %s", ErrBean, name, found, reflect.TypeFor[T]().String())
+	}
+	return bean, nil
+}
+
+// ProvideAs 以类型参数注册结构体原型，等价于 c.Provide(T{}, opts...)。
+// T 可为结构体或结构体指针类型（如 di.ProvideAs[UserService](c)）；其他类型 panic（ErrDefinition）。
+func ProvideAs[T any](c DI, opts ...BeanOption) DI {
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Errorf("%w: ProvideAs expects a struct type, got %s", ErrDefinition, reflect.TypeFor[T]().String()))
+	}
+	return c.Provide(reflect.New(t).Elem().Interface(), opts...)
+}
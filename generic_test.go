@@ -0,0 +1,136 @@
+package di
+
+import (
+	"errors"
+	"testing"
+)
+
+type gStorage interface{ Kind() string }
+
+type gDisk struct{}
+
+func (*gDisk) Kind() string { return "disk" }
+
+type gMemory struct{}
+
+func (*gMemory) Kind() string { return "memory" }
+
+type gService struct {
+	Storage gStorage `aware:""`
+}
+
+func newGenericContainer() *di {
+	c := New()
+	c.RegisterBean(&gDisk{})
+	c.RegisterBean(&gMemory{})
+	ProvideAs[gService](c)
+	c.Load()
+	return c
+}
+
+// TestGeneric_Get 按指针/接口类型获取，多实现走 BeanSelector
+func TestGeneric_Get(t *testing.T) {
+	c := newGenericContainer()
+
+	svc, err := Get[*gService](c)
+	if err != nil || svc.Storage == nil {
+		t.Fatalf("want gService, got %v, %v", svc, err)
+	}
+	storage, err := Get[gStorage](c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 默认 LastRegistered
+	if storage.Kind() != "memory" {
+		t.Fatalf("want last registered memory, got %s", storage.Kind())
+	}
+	if _, err := Get[*gCounted](c); !errors.Is(err, ErrBean) {
+		t.Fatalf("want ErrBean for missing bean, got %v", err)
+	}
+	if _, err := Get[gService](c); !errors.Is(err, ErrDefinition) {
+		t.Fatalf("want ErrDefinition for value type parameter, got %v", err)
+	}
+}
+
+// TestGeneric_SelectorError 选择策略报错以 error 返回
+func TestGeneric_SelectorError(t *testing.T) {
+	c := New()
+	c.WithBeanSelector(ErrorOnAmbiguous{})
+	c.RegisterBean(&gDisk{})
+	c.RegisterBean(&gMemory{})
+	c.Load()
+	if _, err := Get[gStorage](c); !errors.Is(err, ErrBean) {
+		t.Fatalf("want ambiguous error, got %v", err)
+	}
+}
+
+// TestGeneric_MustGet 找不到时 panic
+func TestGeneric_MustGet(t *testing.T) {
+	c := newGenericContainer()
+	if MustGet[*gDisk](c) == nil {
+		t.Fatal("want gDisk")
+	}
+	defer func() {
+		if r := recover(); r == nil || !errors.Is(r.(error), ErrBean) {
+			t.Fatalf("want ErrBean panic, got %v", r)
+		}
+	}()
+	empty := New()
+	empty.Load()
+	MustGet[*gService](empty)
+}
+
+type gCounted struct{ _ int }
+
+var gCountedInits int
+
+func (*gCounted) Initialized() { gCountedInits++ }
+
+// TestGeneric_NotLoaded Load 之前返回 ErrNotLoaded，不提前创建原型或延迟单例
+func TestGeneric_NotLoaded(t *testing.T) {
+	gCountedInits = 0
+	c := New()
+	c.Provide(gCounted{}, WithScope(ScopePrototype))
+	c.ProvideNamedBean("lazyCounted", gCounted{}, WithLazyInit())
+	if _, err := Get[*gCounted](c); !errors.Is(err, ErrNotLoaded) {
+		t.Fatalf("want ErrNotLoaded, got %v", err)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil || !errors.Is(r.(error), ErrNotLoaded) {
+				t.Fatalf("want ErrNotLoaded panic, got %v", r)
+			}
+		}()
+		MustGet[*gCounted](c)
+	}()
+	if gCountedInits != 0 {
+		t.Fatalf("want no instance created before Load, got %d Initialized", gCountedInits)
+	}
+}
+
+// TestGeneric_GetAll 按注册顺序返回所有实现
+func TestGeneric_GetAll(t *testing.T) {
+	c := newGenericContainer()
+	all, err := GetAll[gStorage](c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].Kind() != "disk" || all[1].Kind() != "memory" {
+		t.Fatalf("want [disk memory], got %v", all)
+	}
+}
+
+// TestGeneric_Named 按名称获取并断言类型
+func TestGeneric_Named(t *testing.T) {
+	c := newGenericContainer()
+	disk, err := Named[gStorage](c, "gDisk")
+	if err != nil || disk.Kind() != "disk" {
+		t.Fatalf("want disk, got %v, %v", disk, err)
+	}
+	if _, err := Named[*gService](c, "gDisk"); !errors.Is(err, ErrBean) {
+		t.Fatalf("want type mismatch error, got %v", err)
+	}
+	if _, err := Named[*gService](c, "missing"); !errors.Is(err, ErrBean) {
+		t.Fatalf("want notfound error, got %v", err)
+	}
+}
@@ -37,16 +37,48 @@ func RegisterNamedBean(name string, bean any) DI {
 	return container().RegisterNamedBean(name, bean)
 }
 
-func Provide(prototype any) DI {
-	return container().Provide(prototype)
+func Provide(prototype any, opts ...BeanOption) DI {
+	return container().Provide(prototype, opts...)
 }
 
-func ProvideNamedBean(beanName string, prototype any) DI {
-	return container().ProvideNamedBean(beanName, prototype)
+func ProvideNamedBean(beanName string, prototype any, opts ...BeanOption) DI {
+	return container().ProvideNamedBean(beanName, prototype, opts...)
 }
 
-func ProvideFunc(fn any) DI {
-	return container().ProvideFunc(fn)
+func ProvideFunc(fn any, opts ...BeanOption) DI {
+	return container().ProvideFunc(fn, opts...)
+}
+
+func ProvideNamedFunc(beanName string, fn any, opts ...BeanOption) DI {
+	return container().ProvideNamedFunc(beanName, fn, opts...)
+}
+
+func Decorate(fn any) DI {
+	return container().Decorate(fn)
+}
+
+func DecorateNamed(beanName string, fn any) DI {
+	return container().DecorateNamed(beanName, fn)
+}
+
+func AddBeanPostProcessor(processors ...BeanPostProcessor) DI {
+	return container().AddBeanPostProcessor(processors...)
+}
+
+func AllowBeanOverriding(enable bool) DI {
+	return container().AllowBeanOverriding(enable)
+}
+
+func Override(beanName string, bean any) DI {
+	return container().Override(beanName, bean)
+}
+
+func OverrideType(beanType any, bean any) DI {
+	return container().OverrideType(beanType, bean)
+}
+
+func Install(mods ...*Module) DI {
+	return container().Install(mods...)
 }
 
 func GetBean(beanName string) (bean any, ok bool) {
@@ -103,6 +135,18 @@ func LoadProperties(prefix string, propertyType any) any {
 	return container().LoadProperties(prefix, propertyType)
 }
 
+func WithProfiles(profiles ...string) DI {
+	return container().WithProfiles(profiles...)
+}
+
+func ActiveProfiles() []string {
+	return container().ActiveProfiles()
+}
+
+func SetProfilePropertyMap(profile string, properties map[string]any) DI {
+	return container().SetProfilePropertyMap(profile, properties)
+}
+
 // AutoMigrateEnv 读取所有环境变量注入全局容器配置（_ → .）。
 func AutoMigrateEnv() {
 	container().AutoMigrateEnv()
@@ -112,10 +156,18 @@ func Load() {
 	container().Load()
 }
 
+func LoadE() error {
+	return container().LoadE()
+}
+
 func Serve(ctx context.Context) {
 	container().Serve(ctx)
 }
 
+func ServeE(ctx context.Context) error {
+	return container().ServeE(ctx)
+}
+
 func LoadAndServ(ctx context.Context) {
 	container().Load()
 	container().Serve(ctx)
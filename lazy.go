@@ -0,0 +1,148 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// Lazy 延迟依赖：以 aware 标签声明为 Lazy[T] 字段（T 为指针或接口类型），
+// 注入时只绑定解析方式，首次调用 Get 时才查找目标 bean。
+// 目标以 WithLazyInit 注册时此刻才创建并走完整生命周期
+// （BeanConstruct → 注入 → AfterPropertiesSet → Initialized）。
+//
+//	type Service struct {
+//		DB di.Lazy[*sql.DB] `aware:""`
+//	}
+//
+// 解析规则与普通 aware 字段一致（按名称，接口按类型 + BeanSelector，支持 omitempty）。
+// 并发调用 Get 只解析一次。Lazy 字段不计入循环依赖检测。
+type Lazy[T any] struct {
+	once    sync.Once
+	resolve func() (any, error)
+	value   T
+	err     error
+}
+
+// lazyBinder Lazy[T] 的非泛型视图，供容器识别字段并绑定解析方式
+type lazyBinder interface {
+	lazyTarget() reflect.Type
+	bind(resolve func() (any, error))
+}
+
+var lazyBinderType = reflect.TypeFor[lazyBinder]()
+
+func (l *Lazy[T]) lazyTarget() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (l *Lazy[T]) bind(resolve func() (any, error)) {
+	l.resolve = resolve
+}
+
+// Resolve 返回目标 bean，首次调用时解析。找不到（非 omitempty）或创建失败返回错误（包装 ErrBean）；
+// omitempty 且找不到时返回零值与 nil。
+func (l *Lazy[T]) Resolve() (T, error) {
+	l.once.Do(func() {
+		if l.resolve == nil {
+			l.err = fmt.Errorf("%w: lazy dependency %s not wired by container", ErrBean, reflect.TypeFor[T]().String())
+			return
+		}
+		bean, err := l.resolve()
+		if bean != nil {
+			l.value = bean.(T)
+		}
+		l.err = err
+	})
+	return l.value, l.err
+}
+
+// Get 同 Resolve，出错时 panic（错误可用 errors.Is 判断）。
+func (l *Lazy[T]) Get() T {
+	bean, err := l.Resolve()
+	if err != nil {
+		panic(err)
+	}
+	return bean
+}
+
+// bindLazy 为 bean 的 Lazy[T] 字段绑定解析方式
+func (container *di) bindLazy(bean reflect.Value, def definition, filedName string, awareInfo aware, owner any) {
+	field := bean.FieldByName(filedName)
+	if container.unsafe {
+		field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+	}
+	container.log.Debug(fmt.Sprintf("bind lazy field for %s(%s.%s)", def.Name, def.Type.String(), filedName))
+	field.Addr().Interface().(lazyBinder).bind(func() (any, error) {
+		return container.resolveLazy(awareInfo, def, filedName, owner)
+	})
+}
+
+// resolveLazy 解析 Lazy[T] 字段的目标 bean（首次 Get 时调用）
+func (container *di) resolveLazy(awareInfo aware, def definition, filedName string, owner any) (any, error) {
+	awareBean, ok, err := container.resolveAware(awareInfo, def, filedName, owner)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if awareInfo.Omitempty {
+			container.log.Warn(fmt.Sprintf("Omitempty: lazy dependent bean %s not found for %s(%s.%s)",
+				awareInfo.target(), def.Name, def.Type.String(), filedName))
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %s notfound for %s(%s.%s)",
+			ErrBean, awareInfo.target(), def.Name, def.Type.String(), filedName)
+	}
+	if !reflect.TypeOf(awareBean).AssignableTo(awareInfo.Type) {
+		return nil, fmt.Errorf("%w: %s(%T) not match for %s(%s.%s) need type %s",
+			ErrBean, awareInfo.Name, awareBean, def.Name, def.Type.String(), filedName, awareInfo.Type.String())
+	}
+	return awareBean, nil
+}
+
+// lazyInitBean 创建 WithLazyInit 声明的单例（每个定义只创建一次），完成注入、后置处理与装饰后放入 beanMap，再触发 Initialized；
+// Load 触发 Initialized 之前被创建的，Initialized 推迟到 initialized() 中按生命周期顺序触发。
+// 创建期间并发的查找等待创建完成；延迟单例之间不能成环（Load 时检测），否则创建会重入自身。
+// 创建失败时返回 nil（错误已通过 fail 上报）。
+func (container *di) lazyInitBean(def definition) any {
+	container.mu.Lock()
+	once, ok := container.lazyInits[def.Name]
+	if !ok {
+		once = &sync.Once{}
+		container.lazyInits[def.Name] = once
+	}
+	container.mu.Unlock()
+
+	once.Do(func() {
+		container.log.Info(fmt.Sprintf("lazy initialize bean %s", def.Name))
+		prototype := container.instanceBean(def)
+		if prototype == nil {
+			return
+		}
+		container.constructBean(def.Name, prototype)
+		processed, replaced := container.processBean(prototype, def, prototype)
+		bean, decorated := container.decorateBean(def.Name, processed, prototype)
+		if bean == nil {
+			return
+		}
+		container.mu.Lock()
+		container.beanMap[def.Name] = bean
+		if replaced || decorated {
+			container.originals[def.Name] = prototype
+		}
+		// Load 尚未触发 Initialized 时推迟到 initialized()，与其他 bean 按生命周期顺序一起触发
+		deferInit := !container.initStarted
+		if deferInit {
+			container.deferredInits[def.Name] = true
+		}
+		container.mu.Unlock()
+		if !deferInit {
+			container.initializedBean(def.Name, prototype)
+		}
+	})
+
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+	return container.beanMap[def.Name]
+}
@@ -0,0 +1,206 @@
+package di
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// lazyPool 延迟创建的昂贵依赖，记录生命周期
+type lazyPool struct {
+	Conf *lazyConf `aware:""`
+	DSN  string    `value:"db.dsn"`
+}
+
+var lazyPoolCreated atomic.Int32
+
+func (*lazyPool) BeanConstruct()      { lazyPoolCreated.Add(1); testRecorder.add("pool-construct") }
+func (*lazyPool) AfterPropertiesSet() { testRecorder.add("pool-after-props") }
+func (*lazyPool) Initialized()        { testRecorder.add("pool-initialized") }
+func (*lazyPool) Destroy()            { testRecorder.add("pool-destroy") }
+
+type lazyConf struct{ _ int }
+
+type lazyStore interface{ Store() string }
+
+func (*lazyPool) Store() string { return "pool" }
+
+type lazyRepo struct {
+	Pool  Lazy[*lazyPool] `aware:""`
+	Store Lazy[lazyStore] `aware:""`
+	Cache Lazy[*lazyConf] `aware:"missing,omitempty"`
+}
+
+// TestLazy_FirstAccess 目标在首次 Get 时创建并走完整生命周期
+func TestLazy_FirstAccess(t *testing.T) {
+	testRecorder.reset()
+	lazyPoolCreated.Store(0)
+	c := New()
+	c.RegisterBean(&lazyConf{})
+	c.SetProperty("db.dsn", "mem://")
+	c.Provide(lazyPool{}, WithLazyInit())
+	c.Provide(lazyRepo{})
+	c.Load()
+
+	if lazyPoolCreated.Load() != 0 {
+		t.Fatal("lazy-init bean should not be created by Load")
+	}
+	bean, _ := c.GetBean("lazyRepo")
+	repo := bean.(*lazyRepo)
+	pool := repo.Pool.Get()
+	if pool == nil || pool.Conf == nil || pool.DSN != "mem://" {
+		t.Fatalf("expected lazy target fully wired, got %+v", pool)
+	}
+	want := []string{"pool-construct", "pool-after-props", "pool-initialized"}
+	if got := testRecorder.events; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	if repo.Store.Get() != lazyStore(pool) {
+		t.Fatal("want interface lazy field resolved to the same singleton")
+	}
+	if cache, err := repo.Cache.Resolve(); err != nil || cache != nil {
+		t.Fatalf("want omitempty lazy field resolved to nil, got %v, %v", cache, err)
+	}
+	if got, _ := c.GetBean("lazyPool"); got != pool || lazyPoolCreated.Load() != 1 {
+		t.Fatal("want lazy-init bean created once and registered as singleton")
+	}
+
+	c.destroyBeans()
+	if testRecorder.events[len(testRecorder.events)-1] != "pool-destroy" {
+		t.Fatalf("want lazy bean destroyed with container, got %v", testRecorder.events)
+	}
+}
+
+// TestLazy_Concurrent 并发首次访问只创建一次
+func TestLazy_Concurrent(t *testing.T) {
+	lazyPoolCreated.Store(0)
+	c := New()
+	c.RegisterBean(&lazyConf{})
+	c.Provide(lazyPool{}, WithLazyInit())
+	c.Provide(lazyRepo{})
+	c.Load()
+
+	bean, _ := c.GetBean("lazyRepo")
+	repo := bean.(*lazyRepo)
+	var wg sync.WaitGroup
+	pools := make([]*lazyPool, 8)
+	for i := range pools {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				pools[i] = repo.Pool.Get()
+			} else {
+				found, _ := c.GetBean("lazyPool")
+				pools[i] = found.(*lazyPool)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, p := range pools {
+		if p == nil || p != pools[0] {
+			t.Fatal("want the same instance for all concurrent accesses")
+		}
+	}
+	if lazyPoolCreated.Load() != 1 {
+		t.Fatalf("want lazy bean created once, got %d", lazyPoolCreated.Load())
+	}
+}
+
+type lazyMissing struct {
+	Pool Lazy[*lazyPool] `aware:""`
+}
+
+// TestLazy_NotFound 目标不存在时 Load 成功，Get 时报错
+func TestLazy_NotFound(t *testing.T) {
+	c := New()
+	c.Provide(lazyMissing{})
+	if err := c.LoadE(); err != nil {
+		t.Fatalf("lazy field should not be resolved during Load, got %v", err)
+	}
+	bean, _ := c.GetBean("lazyMissing")
+	if _, err := bean.(*lazyMissing).Pool.Resolve(); !errors.Is(err, ErrBean) {
+		t.Fatalf("want ErrBean, got %v", err)
+	}
+}
+
+type lazyCycleA struct {
+	B *lazyCycleB `aware:""`
+}
+
+type lazyCycleB struct {
+	A Lazy[*lazyCycleA] `aware:""`
+}
+
+type lazyEagerA struct {
+	B *lazyEagerB `aware:""`
+}
+
+type lazyEagerB struct {
+	A *lazyEagerA `aware:""`
+}
+
+// TestLazy_Cycle Lazy 字段打破循环依赖检测；延迟单例之间的环在 Load 时报错
+func TestLazy_Cycle(t *testing.T) {
+	c := New().WithCircularCheck(true)
+	c.Provide(lazyCycleA{})
+	c.Provide(lazyCycleB{})
+	if err := c.LoadE(); err != nil {
+		t.Fatalf("lazy field should break cycle, got %v", err)
+	}
+	a, _ := c.GetBean("lazyCycleA")
+	if a.(*lazyCycleA).B.A.Get() != a {
+		t.Fatal("want lazy field resolved to the singleton")
+	}
+
+	c = New()
+	c.Provide(lazyEagerA{}, WithLazyInit())
+	c.Provide(lazyEagerB{}, WithLazyInit())
+	if err := c.LoadE(); !errors.Is(err, ErrCircularDependency) {
+		t.Fatalf("want ErrCircularDependency for lazy-init cycle, got %v", err)
+	}
+}
+
+// lazyEarly 延迟单例，被普通单例直接依赖而在 Load 期间创建
+type lazyEarly struct{ _ int }
+
+type lazyEarlyUser struct {
+	Early *lazyEarly `aware:""`
+}
+
+type lazyEarlyLate struct{ _ int }
+
+func (*lazyEarly) AfterPropertiesSet()     { testRecorder.add("props:early") }
+func (*lazyEarly) Initialized()            { testRecorder.add("init:early") }
+func (*lazyEarlyUser) AfterPropertiesSet() { testRecorder.add("props:user") }
+func (*lazyEarlyUser) Initialized()        { testRecorder.add("init:user") }
+func (*lazyEarlyLate) AfterPropertiesSet() { testRecorder.add("props:late") }
+func (*lazyEarlyLate) Initialized()        { testRecorder.add("init:late") }
+
+// TestLazy_CreatedDuringLoad Load 期间被提前创建的延迟单例，Initialized 推迟到所有 bean 完成注入之后按生命周期顺序触发
+func TestLazy_CreatedDuringLoad(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		testRecorder.reset()
+		c := New()
+		c.WithParallelInit(workers)
+		c.Provide(lazyEarly{}, WithLazyInit())
+		c.Provide(lazyEarlyUser{})
+		c.Provide(lazyEarlyLate{})
+		c.Load()
+
+		if got := recorded("init:"); workers == 1 && got != "early,user,late" {
+			t.Fatalf("want Initialized in lifecycle order, got %s", got)
+		}
+		if n := strings.Count(recorded("init:"), "early"); n != 1 {
+			t.Fatalf("workers=%d: want Initialized once, got %d", workers, n)
+		}
+		first := slices.IndexFunc(testRecorder.events, func(e string) bool { return strings.HasPrefix(e, "init:") })
+		if wired := strings.Count(strings.Join(testRecorder.events[:first], ","), "props:"); wired != 3 {
+			t.Fatalf("workers=%d: want Initialized after all beans wired, got %v", workers, testRecorder.events)
+		}
+		c.destroyBeans()
+	}
+}
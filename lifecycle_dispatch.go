@@ -74,7 +74,11 @@ func (container *di) initializedBean(beanName string, bean any) {
 	)
 }
 
+// destroyBean 触发 bean 的 Destroy 回调与工厂返回的 cleanup，随后倒序销毁为其创建的原型作用域实例。
 func (container *di) destroyBean(beanName string, bean any) {
+	defer container.destroyDependents(bean)
+	// 工厂返回的 cleanup 在 Destroy 之后执行
+	defer container.runCleanup(beanName, bean)
 	dispatchLifecycle(bean,
 		func(v DisposableWithContainer) {
 			container.log.Debug(fmt.Sprintf("call lifecycle interface DisposableWithContainer for %s(%T)", beanName, bean))
@@ -0,0 +1,75 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLoadFailed LoadE 加载失败。容器进入失败状态后，Load/LoadE/Serve/ServeE 均返回（或 panic）该错误。
+var ErrLoadFailed = errors.New("di load failed")
+
+// BeanError 描述一次 bean 装配失败（实例化、value 注入、aware 注入、工厂调用等）。
+// Error() 与被包装错误一致，可用 errors.Is 判断 ErrBean 等哨兵值，
+// 用 errors.As 取出出错的 bean 与字段。
+type BeanError struct {
+	Bean  string // 出错的 bean 名称
+	Field string // 出错的字段名；非字段级错误（如工厂调用）为空
+	Err   error  // 原始错误
+}
+
+func (e *BeanError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BeanError) Unwrap() error {
+	return e.Err
+}
+
+// fail 上报一次装配错误。
+// LoadE 期间收集到错误列表，继续装配其余 bean；其余情况交给 Log.Fatal（默认 panic）。
+func (container *di) fail(beanName, field string, err error) {
+	err = &BeanError{Bean: beanName, Field: field, Err: err}
+	container.errMu.Lock()
+	if container.collecting {
+		container.loadErrs = append(container.loadErrs, err)
+		container.errMu.Unlock()
+		return
+	}
+	container.errMu.Unlock()
+	container.log.Fatal(err)
+}
+
+// beginCollect 进入错误收集模式（LoadE）。
+func (container *di) beginCollect() {
+	container.errMu.Lock()
+	defer container.errMu.Unlock()
+	container.collecting = true
+	container.loadErrs = nil
+}
+
+// endCollect 退出错误收集模式，返回收集到的错误。
+func (container *di) endCollect() []error {
+	container.errMu.Lock()
+	defer container.errMu.Unlock()
+	container.collecting = false
+	errs := container.loadErrs
+	container.loadErrs = nil
+	return errs
+}
+
+// hasFailed 判断 LoadE 期间 bean 是否已上报过错误（如 value 注入失败）。
+func (container *di) hasFailed(beanName string) bool {
+	container.errMu.Lock()
+	defer container.errMu.Unlock()
+	for _, err := range container.loadErrs {
+		if be, ok := err.(*BeanError); ok && be.Bean == beanName {
+			return true
+		}
+	}
+	return false
+}
+
+// loadFailed 将收集到的错误合并为一个错误：首个为 ErrLoadFailed 摘要，其后依次为各 bean 的错误。
+func loadFailed(errs []error) error {
+	return errors.Join(append([]error{fmt.Errorf("%w: %d error(s)", ErrLoadFailed, len(errs))}, errs...)...)
+}
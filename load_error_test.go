@@ -0,0 +1,126 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type leMissingA struct{}
+
+type leMissingB struct{}
+
+// leBroken 两个缺失依赖 + 一个无法转换的配置项
+type leBroken struct {
+	A    *leMissingA `aware:""`
+	B    *leMissingB `aware:""`
+	Port int         `value:"le.port"`
+}
+
+type leFine struct{}
+
+func (*leFine) Initialized() { testRecorder.add("le-fine-initialized") }
+
+// TestLoadE_CollectsAllErrors LoadE 收集所有错误而非在第一个处 panic
+func TestLoadE_CollectsAllErrors(t *testing.T) {
+	testRecorder.reset()
+	c := New()
+	c.SetProperty("le.port", "not-a-number")
+	c.Provide(leBroken{})
+	c.Provide(leFine{})
+
+	err := c.LoadE()
+	if err == nil {
+		t.Fatal("expected LoadE error")
+	}
+	if !errors.Is(err, ErrLoadFailed) || !errors.Is(err, ErrBean) {
+		t.Fatalf("want ErrLoadFailed and ErrBean, got %v", err)
+	}
+	fields := map[string]bool{}
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var be *BeanError
+		if errors.As(e, &be) {
+			if be.Bean != "leBroken" {
+				t.Fatalf("want bean leBroken, got %q", be.Bean)
+			}
+			fields[be.Field] = true
+		}
+	}
+	for _, f := range []string{"A", "B", "Port"} {
+		if !fields[f] {
+			t.Fatalf("want error for field %s, got %v", f, err)
+		}
+	}
+	// 失败时不触发 Initialized
+	if len(testRecorder.events) != 0 {
+		t.Fatalf("want no Initialized callbacks, got %v", testRecorder.events)
+	}
+}
+
+// TestLoadE_FailedState 失败后容器进入失败状态
+func TestLoadE_FailedState(t *testing.T) {
+	c := New()
+	c.RegisterBean(&leMissingA{})
+	c.Provide(leBroken{})
+	err := c.LoadE()
+	if err == nil {
+		t.Fatal("expected LoadE error")
+	}
+	if _, ok := c.GetBean("leBroken"); ok {
+		t.Fatal("failed bean should be discarded")
+	}
+	if _, ok := c.GetBean("leMissingA"); !ok {
+		t.Fatal("registered instance should be kept")
+	}
+	if again := c.LoadE(); !errors.Is(again, ErrLoadFailed) {
+		t.Fatalf("want ErrLoadFailed on reload, got %v", again)
+	}
+	if serveErr := c.ServeE(context.Background()); !errors.Is(serveErr, ErrLoadFailed) {
+		t.Fatalf("want ErrLoadFailed from ServeE, got %v", serveErr)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil || !errors.Is(r.(error), ErrLoadFailed) {
+				t.Fatalf("want Load panic with ErrLoadFailed, got %v", r)
+			}
+		}()
+		c.Load()
+	}()
+}
+
+// TestLoadE_Success 无错误时与 Load 一致
+func TestLoadE_Success(t *testing.T) {
+	c := New()
+	c.RegisterBean(&leMissingA{})
+	c.RegisterBean(&leMissingB{})
+	c.Provide(leBroken{})
+	if err := c.LoadE(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.LoadE(); !errors.Is(err, ErrLoaded) {
+		t.Fatalf("want ErrLoaded, got %v", err)
+	}
+	bean, _ := c.GetBean("leBroken")
+	if bean.(*leBroken).A == nil || bean.(*leBroken).B == nil {
+		t.Fatal("expected dependencies injected")
+	}
+}
+
+// TestLoadE_FactoryArgMissing 工厂入参缺失作为错误收集
+func TestLoadE_FactoryArgMissing(t *testing.T) {
+	c := New()
+	c.ProvideFunc(func(a *leMissingA) *leFine { return &leFine{} })
+	err := c.LoadE()
+	if err == nil || !strings.Contains(err.Error(), "factory arg") {
+		t.Fatalf("want factory arg error, got %v", err)
+	}
+}
+
+// TestServeE_NotLoaded 未 Load 时 ServeE 返回 ErrNotLoaded
+func TestServeE_NotLoaded(t *testing.T) {
+	c := New()
+	if err := c.ServeE(context.Background()); !errors.Is(err, ErrNotLoaded) {
+		t.Fatalf("want ErrNotLoaded, got %v", err)
+	}
+}
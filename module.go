@@ -0,0 +1,164 @@
+package di
+
+import (
+	"fmt"
+	"maps"
+)
+
+// Module 可复用的注册单元：打包一组 bean 注册、默认配置与子模块导入，以 Install 安装到容器。
+// 库可以提供 Module 代替安装说明：
+//
+//	var Module = di.NewModule("redis").
+//		SetDefaultProperty("redis.addr", "localhost:6379").
+//		ProvideFunc(newClient)
+//
+//	c.Install(redis.Module, cache.Module)
+//
+// 同一个 Module 在同一容器中只安装一次（被多个模块导入也是）。Module 只记录注册，安装前不会访问容器。
+type Module struct {
+	name      string
+	imports   []*Module
+	defaults  map[string]any
+	registers []func(c DI)
+}
+
+// NewModule 创建模块，name 用于去重校验与诊断（GetBeanModule/DescribeBean）
+func NewModule(name string) *Module {
+	return &Module{name: name, defaults: map[string]any{}}
+}
+
+// Name 返回模块名称
+func (m *Module) Name() string {
+	return m.name
+}
+
+// Import 导入子模块：安装本模块时先安装子模块
+func (m *Module) Import(mods ...*Module) *Module {
+	m.imports = append(m.imports, mods...)
+	return m
+}
+
+// SetDefaultProperty 设置模块的默认配置项（以 SetDefaultProperty 写入，应用的配置优先）
+func (m *Module) SetDefaultProperty(key string, value any) *Module {
+	m.defaults[key] = value
+	return m
+}
+
+// SetDefaultPropertyMap 批量设置模块的默认配置项
+func (m *Module) SetDefaultPropertyMap(properties map[string]any) *Module {
+	maps.Copy(m.defaults, properties)
+	return m
+}
+
+// RegisterBean 同 DI.RegisterBean
+func (m *Module) RegisterBean(bean any) *Module {
+	return m.register(func(c DI) { c.RegisterBean(bean) })
+}
+
+// RegisterNamedBean 同 DI.RegisterNamedBean
+func (m *Module) RegisterNamedBean(name string, bean any) *Module {
+	return m.register(func(c DI) { c.RegisterNamedBean(name, bean) })
+}
+
+// Provide 同 DI.Provide
+func (m *Module) Provide(prototype any, opts ...BeanOption) *Module {
+	return m.register(func(c DI) { c.Provide(prototype, opts...) })
+}
+
+// ProvideNamedBean 同 DI.ProvideNamedBean
+func (m *Module) ProvideNamedBean(beanName string, prototype any, opts ...BeanOption) *Module {
+	return m.register(func(c DI) { c.ProvideNamedBean(beanName, prototype, opts...) })
+}
+
+// ProvideFunc 同 DI.ProvideFunc
+func (m *Module) ProvideFunc(fn any, opts ...BeanOption) *Module {
+	return m.register(func(c DI) { c.ProvideFunc(fn, opts...) })
+}
+
+// ProvideNamedFunc 同 DI.ProvideNamedFunc
+func (m *Module) ProvideNamedFunc(beanName string, fn any, opts ...BeanOption) *Module {
+	return m.register(func(c DI) { c.ProvideNamedFunc(beanName, fn, opts...) })
+}
+
+// Decorate 同 DI.Decorate
+func (m *Module) Decorate(fn any) *Module {
+	return m.register(func(c DI) { c.Decorate(fn) })
+}
+
+// DecorateNamed 同 DI.DecorateNamed
+func (m *Module) DecorateNamed(beanName string, fn any) *Module {
+	return m.register(func(c DI) { c.DecorateNamed(beanName, fn) })
+}
+
+func (m *Module) register(fn func(c DI)) *Module {
+	m.registers = append(m.registers, fn)
+	return m
+}
+
+// Install 安装模块：按导入顺序深度优先安装子模块，再写入本模块的默认配置与注册。
+// 已安装的模块（含被其他模块导入的）跳过，注册中途 Fatal 的模块不记为已安装；不同模块同名时 Fatal（ErrDefinition）。
+// 与注册方法一样必须在 Load 前调用，不能并发调用。
+func (container *di) Install(mods ...*Module) DI {
+	if container.loaded.Load() {
+		container.log.Fatal(fmt.Errorf("%w", ErrLoaded))
+		return container
+	}
+	for _, m := range mods {
+		container.install(m)
+	}
+	return container
+}
+
+func (container *di) install(m *Module) {
+	if installed, exist := container.modules[m.name]; exist {
+		if installed != m {
+			container.log.Fatal(fmt.Errorf("%w: module %s already installed by another module instance", ErrDefinition, m.name))
+		}
+		return
+	}
+	// 先标记再安装子模块，导入成环时不会重复安装；注册中途 Fatal（panic）时撤销标记，recover 后可重新安装
+	container.modules[m.name] = m
+	installed := false
+	defer func() {
+		if !installed {
+			delete(container.modules, m.name)
+		}
+	}()
+	for _, imported := range m.imports {
+		container.install(imported)
+	}
+	container.log.Info(fmt.Sprintf("install module %s", m.name))
+	for key, value := range m.defaults {
+		container.valueStore.SetDefault(key, value)
+	}
+	parentModule := container.installing
+	container.installing = m.name
+	defer func() { container.installing = parentModule }()
+	for _, register := range m.registers {
+		register(container)
+	}
+	installed = true
+}
+
+// recordModule 记录正在安装的模块为 beanName 的来源（调用方持写锁）；
+// 不在安装期间时清除记录（同名注册替换了模块注册的后备定义）
+func (container *di) recordModule(beanName string) {
+	if container.installing != "" {
+		container.beanModules[beanName] = container.installing
+	} else {
+		delete(container.beanModules, beanName)
+	}
+}
+
+// GetBeanModule 返回注册 beanName 的模块名称；bean 不存在或不是由模块注册的返回 ok=false。
+func (container *di) GetBeanModule(beanName string) (module string, ok bool) {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+	_, isBean := container.beanMap[beanName]
+	_, isDef := container.beanDefinitionMap[beanName]
+	if !isBean && !isDef {
+		return "", false
+	}
+	module, ok = container.beanModules[beanName]
+	return
+}
@@ -0,0 +1,93 @@
+package di
+
+import (
+	"errors"
+	"testing"
+)
+
+type modClient struct {
+	Addr string `value:"redis.addr"`
+}
+
+type modCache struct {
+	Client *modClient `aware:""`
+}
+
+type modConfig struct{ _ int }
+
+func newModClient() *modClient { return &modClient{} }
+
+// TestModule_Install 模块安装：子模块先安装，默认配置可被应用覆盖
+func TestModule_Install(t *testing.T) {
+	redis := NewModule("redis").
+		SetDefaultProperty("redis.addr", "localhost:6379").
+		Provide(modClient{})
+	cache := NewModule("cache").
+		Import(redis).
+		Provide(modCache{})
+
+	c := New()
+	c.SetProperty("redis.addr", "redis:6379")
+	c.RegisterBean(&modConfig{})
+	c.Install(cache, redis)
+	c.Load()
+
+	bean, ok := c.GetBean("modCache")
+	if !ok || bean.(*modCache).Client == nil {
+		t.Fatal("expected module beans wired")
+	}
+	if addr := bean.(*modCache).Client.Addr; addr != "redis:6379" {
+		t.Fatalf("want application property to win over module default, got %s", addr)
+	}
+	if m, ok := c.GetBeanModule("modClient"); !ok || m != "redis" {
+		t.Fatalf("want modClient from redis module, got %q %v", m, ok)
+	}
+	if desc, _ := c.DescribeBean("modCache"); desc.Module != "cache" {
+		t.Fatalf("want modCache from cache module, got %q", desc.Module)
+	}
+	if _, ok := c.GetBeanModule("modConfig"); ok {
+		t.Fatal("bean registered outside modules should have no module")
+	}
+}
+
+// TestModule_Dedup 同一模块被多次导入只安装一次；不同模块同名 panic
+func TestModule_Dedup(t *testing.T) {
+	base := NewModule("base").ProvideFunc(newModClient)
+	a := NewModule("a").Import(base)
+	b := NewModule("b").Import(base)
+
+	c := New()
+	c.Install(a, b, base) // 重复注册 modClient 会 panic
+	c.Load()
+	if _, ok := c.GetBean("modClient"); !ok {
+		t.Fatal("expected modClient installed once")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("want panic for conflicting module name")
+		}
+	}()
+	New().Install(base, NewModule("base"))
+}
+
+// TestModule_InstallFailed 注册失败（Fatal 被 recover）的模块不记为已安装，可在修正后重新安装
+func TestModule_InstallFailed(t *testing.T) {
+	c := New()
+	c.RegisterBean(&modClient{})
+	m := NewModule("client").ProvideFunc(newModClient)
+	func() {
+		defer func() {
+			if r, _ := recover().(error); !errors.Is(r, ErrBean) {
+				t.Fatalf("want ErrBean for duplicate bean, got %v", r)
+			}
+		}()
+		c.Install(m)
+	}()
+
+	c.AllowBeanOverriding(true)
+	c.Install(m)
+	if module, ok := c.GetBeanModule("modClient"); !ok || module != "client" {
+		t.Fatalf("want module installed on retry, got %q %v", module, ok)
+	}
+}
@@ -0,0 +1,67 @@
+package di
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+)
+
+// Ordered 可被 bean 实现，声明在 slice 注入、GetByTypeAll 与工厂 slice 入参中的排序值：
+// 按升序排列，未声明为 0，相同时保持注册顺序。注册选项 WithOrder 优先。
+// 单值注入的多实现选择不受影响，仍由 BeanSelector 按注册顺序决定。
+type Ordered interface {
+	Order() int
+}
+
+// WithOrder 声明 bean 的排序值，覆盖 bean 实现的 Ordered 接口
+func WithOrder(order int) BeanOption {
+	return func(def *definition) {
+		def.order = order
+	}
+}
+
+// declaredOrder 读取实例类型实现的 Ordered 接口声明的排序值，未实现时为 0
+func declaredOrder(instanceType reflect.Type) int {
+	if o, ok := zeroInstance(instanceType).(Ordered); ok {
+		return o.Order()
+	}
+	return 0
+}
+
+// orderOf 返回 bean 的排序值：有定义的取定义上的值，直接注册的实例取其 Ordered 接口；
+// 本容器没有该 bean 时由父容器判断。
+func (container *di) orderOf(beanName string, bean any) int {
+	container.mu.RLock()
+	def, isDef := container.beanDefinitionMap[beanName]
+	_, isBean := container.beanMap[beanName]
+	container.mu.RUnlock()
+	switch {
+	case isDef:
+		return def.order
+	case isBean:
+		if o, ok := bean.(Ordered); ok {
+			return o.Order()
+		}
+		return 0
+	case container.parent != nil:
+		return container.parent.orderOf(beanName, bean)
+	default:
+		return 0
+	}
+}
+
+// sortByOrder 按排序值稳定排序（beans 已按注册顺序排列）
+func (container *di) sortByOrder(beans []BeanWithName) {
+	orders := make(map[string]int, len(beans))
+	for _, b := range beans {
+		orders[b.Name] = container.orderOf(b.Name, b.Bean)
+	}
+	slices.SortStableFunc(beans, func(a, b BeanWithName) int {
+		return cmp.Compare(orders[a.Name], orders[b.Name])
+	})
+}
+
+// isCollectType 判断类型是否为批量注入类型（[]T 或 map[string]T）
+func isCollectType(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice || (t.Kind() == reflect.Map && t.Key().Kind() == reflect.String)
+}
@@ -0,0 +1,82 @@
+package di
+
+import (
+	"strings"
+	"testing"
+)
+
+type orderMiddleware interface{ Name() string }
+
+type orderAuth struct{ _ int }
+
+func (*orderAuth) Name() string { return "auth" }
+func (*orderAuth) Order() int   { return 10 }
+
+type orderLogging struct{ _ int }
+
+func (*orderLogging) Name() string { return "logging" }
+func (*orderLogging) Order() int   { return -10 }
+
+type orderRecover struct{ _ int }
+
+func (*orderRecover) Name() string { return "recover" }
+
+type orderMetrics struct{ _ int }
+
+func (*orderMetrics) Name() string { return "metrics" }
+
+type orderChain struct {
+	Middlewares []orderMiddleware `aware:""`
+}
+
+type orderServer struct {
+	Chain []orderMiddleware
+}
+
+func newOrderServer(chain []orderMiddleware) *orderServer { return &orderServer{Chain: chain} }
+
+func orderNames(beans []orderMiddleware) (names []string) {
+	for _, b := range beans {
+		names = append(names, b.Name())
+	}
+	return names
+}
+
+// TestOrder_Sort slice 注入与 GetByTypeAll 按排序值排列，相同时按注册顺序
+func TestOrder_Sort(t *testing.T) {
+	c := New()
+	c.RegisterBean(&orderAuth{})
+	c.Provide(orderRecover{})
+	c.Provide(orderMetrics{}, WithOrder(10))
+	c.RegisterBean(&orderLogging{})
+	c.Provide(orderChain{})
+	c.Load()
+
+	want := "logging,recover,auth,metrics"
+	bean, _ := c.GetBean("orderChain")
+	if got := strings.Join(orderNames(bean.(*orderChain).Middlewares), ","); got != want {
+		t.Fatalf("want slice injection %s, got %s", want, got)
+	}
+	var all []orderMiddleware
+	for _, b := range c.GetByTypeAll((*orderMiddleware)(nil)) {
+		all = append(all, b.Bean.(orderMiddleware))
+	}
+	if got := strings.Join(orderNames(all), ","); got != want {
+		t.Fatalf("want GetByTypeAll %s, got %s", want, got)
+	}
+}
+
+// TestOrder_FactorySliceArg 工厂 slice 入参按排序值排列
+func TestOrder_FactorySliceArg(t *testing.T) {
+	c := New()
+	c.RegisterBean(&orderAuth{})
+	c.RegisterBean(&orderRecover{})
+	c.RegisterBean(&orderLogging{})
+	c.ProvideFunc(newOrderServer)
+	c.Load()
+
+	server, _ := c.GetBean("orderServer")
+	if got := strings.Join(orderNames(server.(*orderServer).Chain), ","); got != "logging,recover,auth" {
+		t.Fatalf("want factory slice arg sorted, got %s", got)
+	}
+}
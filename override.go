@@ -0,0 +1,110 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AllowBeanOverriding 开启/关闭同名覆盖，默认关闭（重复注册同名 bean 时 Fatal）。
+// 开启后 RegisterNamedBean/ProvideNamedBean/ProvideNamedFunc 重复注册同名 bean 时由后注册的替换先注册的，
+// 打印 warn 日志，替换者沿用被替换者在注册顺序中的位置。必须在注册前调用；Load 之后不能覆盖已注册的 bean（Fatal，ErrLoaded）。
+func (container *di) AllowBeanOverriding(enable bool) DI {
+	container.overriding = enable
+	return container
+}
+
+// Override 以实例 bean（必须是指针）替换已注册的同名 bean（实例或定义），不受 AllowBeanOverriding 限制。
+// 替换者沿用被替换者在注册顺序中的位置；beanName 未注册时 Fatal。必须在 Load 前调用。
+// 常用于测试中以替身替换真实依赖。
+func (container *di) Override(beanName string, bean any) DI {
+	if container.loaded.Load() {
+		container.log.Fatal(fmt.Errorf("%w", ErrLoaded))
+		return container
+	}
+	if !IsPtr(bean) {
+		container.log.Fatal(fmt.Errorf("%w: bean must be a pointer", ErrBean))
+		return container
+	}
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	if !container.removeRegistration(beanName, fmt.Sprintf("%T", bean)) {
+		container.log.Fatal(fmt.Errorf("%w: bean %s not found to override", ErrDefinition, beanName))
+		return container
+	}
+	container.beanMap[beanName] = bean
+	container.recordModule(beanName)
+	return container
+}
+
+// OverrideType 以实例 bean 替换按类型 beanType 唯一匹配的已注册 bean，规则同 Override。
+// beanType 可传值类型或指针类型（如 OverrideType((*Storage)(nil), fake)），bean 必须可赋值给该类型；
+// 没有或有多个匹配时 Fatal（多个匹配请用 Override 指定名称）。
+func (container *di) OverrideType(beanType any, bean any) DI {
+	t := matchType(beanType)
+	if t == nil {
+		container.log.Fatal(fmt.Errorf("%w: OverrideType expects a bean type", ErrDefinition))
+		return container
+	}
+	if !IsPtr(bean) || !reflect.TypeOf(bean).AssignableTo(t) {
+		container.log.Fatal(fmt.Errorf("%w: %T is not a pointer assignable to %s", ErrBean, bean, t.String()))
+		return container
+	}
+	var matched []string
+	container.mu.RLock()
+	for _, name := range container.beanSort {
+		if b, ok := container.beanMap[name]; ok {
+			if container.exposedType(name, b).AssignableTo(t) {
+				matched = append(matched, name)
+			}
+		} else if def, ok := container.beanDefinitionMap[name]; ok && def.instanceType().AssignableTo(t) {
+			matched = append(matched, name)
+		}
+	}
+	container.mu.RUnlock()
+	switch len(matched) {
+	case 0:
+		container.log.Fatal(fmt.Errorf("%w: bean of type %s not found to override", ErrDefinition, t.String()))
+		return container
+	case 1:
+		return container.Override(matched[0], bean)
+	default:
+		container.log.Fatal(fmt.Errorf("%w: %d beans of type %s found to override (%s), use Override with a bean name",
+			ErrDefinition, len(matched), t.String(), strings.Join(matched, ", ")))
+		return container
+	}
+}
+
+// overrideRegistration 处理 AllowBeanOverriding（调用方持写锁）：开启时移除同名的已注册 bean，返回是否替换，
+// 替换者沿用被替换者在注册顺序中的位置。Load 之后同名 bean 已经创建、触发过生命周期回调并可能已被注入，
+// 替换会使其既不销毁也不被依赖方看到，因此返回 ErrLoaded（同 Override）。
+func (container *di) overrideRegistration(beanName string, by string) (bool, error) {
+	if !container.overriding {
+		return false, nil
+	}
+	if container.loaded.Load() {
+		_, isBean := container.beanMap[beanName]
+		_, isDef := container.beanDefinitionMap[beanName]
+		if isBean || isDef {
+			return false, fmt.Errorf("%w: bean %s cannot be overridden after Load", ErrLoaded, beanName)
+		}
+		return false, nil
+	}
+	return container.removeRegistration(beanName, by), nil
+}
+
+// removeRegistration 移除同名的已注册 bean（实例或定义）以便替换，保留其在 beanSort 中的位置（调用方持写锁）。
+// by 为替换者的描述，用于日志。beanName 未注册时返回 false。
+func (container *di) removeRegistration(beanName string, by string) bool {
+	if bean, ok := container.beanMap[beanName]; ok {
+		container.log.Warn(fmt.Sprintf("bean %s(%T) overridden by %s", beanName, bean, by))
+		delete(container.beanMap, beanName)
+		return true
+	}
+	if def, ok := container.beanDefinitionMap[beanName]; ok {
+		container.log.Warn(fmt.Sprintf("bean %s(%s) overridden by %s", beanName, def.Type.String(), by))
+		delete(container.beanDefinitionMap, beanName)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package di
+
+import (
+	"errors"
+	"testing"
+)
+
+type ovStorage interface{ Name() string }
+
+type ovRealStorage struct{ _ int }
+
+func (*ovRealStorage) Name() string { return "real" }
+
+type ovFakeStorage struct{ _ int }
+
+func (*ovFakeStorage) Name() string { return "fake" }
+
+type ovService struct {
+	Storage ovStorage `aware:""`
+}
+
+type ovOther struct{ _ int }
+
+// TestOverride Override/OverrideType 以替身替换已注册的定义或实例，保留注册位置
+func TestOverride(t *testing.T) {
+	c := New()
+	c.ProvideNamedFunc("storage", func() ovStorage { return &ovRealStorage{} })
+	c.Provide(ovService{})
+	c.Provide(ovOther{})
+	c.Override("storage", &ovFakeStorage{})
+	c.Load()
+
+	if got := MustGet[*ovService](c).Storage.Name(); got != "fake" {
+		t.Fatalf("want fake storage injected, got %s", got)
+	}
+	if names := c.GetBeanNames(); len(names) != 3 || names[0] != "storage" {
+		t.Fatalf("want override to keep registration order, got %v", names)
+	}
+
+	c = New()
+	c.RegisterBean(&ovRealStorage{})
+	c.Provide(ovService{})
+	c.OverrideType((*ovStorage)(nil), &ovFakeStorage{})
+	c.Load()
+	if got := MustGet[*ovService](c).Storage.Name(); got != "fake" {
+		t.Fatalf("want fake storage injected by type, got %s", got)
+	}
+	if _, ok := c.GetBean("ovRealStorage"); !ok {
+		t.Fatal("want overridden bean to keep its name")
+	}
+}
+
+// TestOverride_Errors 名称不存在、类型无匹配或有多个匹配时 Fatal
+func TestOverride_Errors(t *testing.T) {
+	expectDefinitionErr := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if r, _ := recover().(error); !errors.Is(r, ErrDefinition) {
+				t.Fatalf("%s: want ErrDefinition, got %v", name, r)
+			}
+		}()
+		fn()
+	}
+	expectDefinitionErr("missing name", func() { New().Override("storage", &ovFakeStorage{}) })
+	expectDefinitionErr("missing type", func() { New().OverrideType((*ovStorage)(nil), &ovFakeStorage{}) })
+	expectDefinitionErr("ambiguous type", func() {
+		c := New()
+		c.RegisterNamedBean("a", &ovRealStorage{})
+		c.RegisterNamedBean("b", &ovRealStorage{})
+		c.OverrideType((*ovStorage)(nil), &ovFakeStorage{})
+	})
+}
+
+// TestAllowBeanOverriding 开启后重复注册同名 bean 由后注册的替换；默认重复注册 Fatal
+func TestAllowBeanOverriding(t *testing.T) {
+	c := New()
+	c.AllowBeanOverriding(true)
+	c.ProvideNamedFunc("storage", func() ovStorage { return &ovRealStorage{} })
+	c.Provide(ovService{})
+	c.ProvideNamedFunc("storage", func() ovStorage { return &ovFakeStorage{} })
+	c.Load()
+	if got := MustGet[*ovService](c).Storage.Name(); got != "fake" {
+		t.Fatalf("want later registration to win, got %s", got)
+	}
+	if names := c.GetBeanNames(); len(names) != 2 || names[0] != "storage" {
+		t.Fatalf("want replaced bean to keep its position, got %v", names)
+	}
+
+	defer func() {
+		if r, _ := recover().(error); !errors.Is(r, ErrDefinition) {
+			t.Fatalf("want ErrDefinition for duplicate without overriding, got %v", r)
+		}
+	}()
+	c = New()
+	c.ProvideNamedFunc("storage", func() ovStorage { return &ovRealStorage{} })
+	c.ProvideNamedFunc("storage", func() ovStorage { return &ovFakeStorage{} })
+}
+
+// TestAllowBeanOverriding_AfterLoad Load 之后覆盖已创建的 bean 时 Fatal（ErrLoaded），原实例保持不变；新名称仍可注册
+func TestAllowBeanOverriding_AfterLoad(t *testing.T) {
+	c := New()
+	c.AllowBeanOverriding(true)
+	orig := &ovRealStorage{}
+	c.RegisterNamedBean("storage", orig)
+	c.Load()
+
+	func() {
+		defer func() {
+			if r, _ := recover().(error); !errors.Is(r, ErrLoaded) {
+				t.Fatalf("want ErrLoaded for overriding after Load, got %v", r)
+			}
+		}()
+		c.RegisterNamedBean("storage", &ovFakeStorage{})
+	}()
+	if got, _ := c.GetBean("storage"); got != orig {
+		t.Fatalf("want original bean kept, got %v", got)
+	}
+	c.RegisterNamedBean("other", &ovOther{})
+	if _, ok := c.GetBean("other"); !ok {
+		t.Fatal("want new bean registered after Load")
+	}
+}
@@ -0,0 +1,105 @@
+package di
+
+import (
+	"fmt"
+	"sync"
+)
+
+// WithParallelInit 设置并行触发 Initialized 回调的 worker 数，workers <= 1 时串行（默认）。
+// 开启后没有依赖关系的 bean 的 Initialized 并发执行，每个 bean 在其依赖（见 WithDependencyOrder）
+// 与前一阶段（见 Phased）所有 bean 的 Initialized 全部返回后才开始；实例化与注入仍串行。
+// 回调须并发安全。必须在 Load 前调用。
+func (container *di) WithParallelInit(workers int) DI {
+	container.workers = workers
+	return container
+}
+
+// initializedParallel 以 container.workers 个 worker 并行触发 Initialized：
+// 按阶段分组的拓扑顺序为每个 bean 确定前置依赖（环中指向后序的边忽略，保证无环；依赖不会位于后续阶段，见 checkPhaseOrder），
+// 并以前一阶段的所有 bean 为前置，依赖全部完成后占用 worker 执行回调。
+// 回调 panic 时不再启动尚未开始的回调，等待进行中的回调返回后在调用方 goroutine 重新抛出首个 panic。
+// deferred 为 Load 期间被提前创建、推迟到此触发 Initialized 的延迟单例。
+func (container *di) initializedParallel(deferred map[string]bool) {
+	order := container.sortByPhase(container.topologicalOrder(container.lifecycleDeps))
+	index := make(map[string]int, len(order))
+	for i, name := range order {
+		index[name] = i
+	}
+	type task struct {
+		name string
+		bean any
+		deps []int
+	}
+	tasks := make([]task, len(order))
+	// 前一阶段与当前阶段在 order 中的起始下标
+	prevStart, phaseStart := 0, 0
+	for i, name := range order {
+		tasks[i].name = name
+		if i > 0 && container.phaseOf(name) != container.phaseOf(order[i-1]) {
+			prevStart, phaseStart = phaseStart, i
+		}
+		for j := prevStart; j < phaseStart; j++ {
+			tasks[i].deps = append(tasks[i].deps, j)
+		}
+		for _, depName := range container.lifecycleDeps(name) {
+			if j, ok := index[depName]; ok && j >= phaseStart && j < i {
+				tasks[i].deps = append(tasks[i].deps, j)
+			}
+		}
+		container.mu.RLock()
+		bean, ok := container.beanMap[name]
+		def := container.beanDefinitionMap[name]
+		container.mu.RUnlock()
+		// 此后才创建的延迟单例由 lazyInitBean 自行触发 Initialized
+		if ok && (!def.lazyInit || deferred[name]) {
+			tasks[i].bean = container.originalOf(name, bean)
+		}
+	}
+
+	container.log.Info(fmt.Sprintf("initialize %d bean(s) with %d workers", len(tasks), container.workers))
+	done := make([]chan struct{}, len(tasks))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	var (
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, container.workers)
+		panicMu  sync.Mutex
+		panicked any
+	)
+	aborted := func() bool {
+		panicMu.Lock()
+		defer panicMu.Unlock()
+		return panicked != nil
+	}
+	for i, t := range tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[i])
+			for _, j := range t.deps {
+				<-done[j]
+			}
+			if t.bean == nil || aborted() {
+				return
+			}
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			defer func() {
+				if r := recover(); r != nil {
+					panicMu.Lock()
+					if panicked == nil {
+						panicked = r
+					}
+					panicMu.Unlock()
+				}
+			}()
+			// 回调在锁外
+			container.initializedBean(t.name, t.bean)
+		}()
+	}
+	wg.Wait()
+	if panicked != nil {
+		panic(panicked)
+	}
+}
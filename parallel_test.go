@@ -0,0 +1,85 @@
+package di
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// piGate 控制并行回调：Initialized 登记开始后等待放行
+type piGate struct {
+	started chan string
+	release chan struct{}
+}
+
+type piSlow struct {
+	name string
+	gate *piGate
+	done atomic.Bool
+}
+
+func (b *piSlow) Initialized() {
+	b.gate.started <- b.name
+	<-b.gate.release
+	b.done.Store(true)
+}
+
+type piDependent struct {
+	A       *piSlow `aware:"a"`
+	B       *piSlow `aware:"b"`
+	sawDeps bool
+}
+
+func (d *piDependent) Initialized() { d.sawDeps = d.A.done.Load() && d.B.done.Load() }
+
+// TestParallelInit 无依赖关系的 Initialized 并发执行且不超过 worker 数；依赖方在依赖完成后执行
+func TestParallelInit(t *testing.T) {
+	gate := &piGate{started: make(chan string, 4), release: make(chan struct{})}
+	c := New()
+	c.WithParallelInit(2)
+	c.ProvideNamedBean("dependent", piDependent{})
+	for _, name := range []string{"a", "b", "c"} {
+		c.RegisterNamedBean(name, &piSlow{name: name, gate: gate})
+	}
+	loaded := make(chan struct{})
+	go func() {
+		defer close(loaded)
+		c.Load()
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-gate.started:
+		case <-time.After(time.Second):
+			t.Fatal("want 2 Initialized callbacks running concurrently")
+		}
+	}
+	select {
+	case name := <-gate.started:
+		t.Fatalf("want at most 2 concurrent callbacks, %s started", name)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(gate.release)
+	<-loaded
+
+	if !MustGet[*piDependent](c).sawDeps {
+		t.Fatal("want dependent initialized after its dependencies")
+	}
+}
+
+type piPanic struct{ _ int }
+
+func (*piPanic) Initialized() { panic("init failed") }
+
+// TestParallelInit_Panic 回调 panic 在 Load 的调用方重新抛出
+func TestParallelInit_Panic(t *testing.T) {
+	c := New()
+	c.WithParallelInit(4)
+	c.RegisterBean(&piPanic{})
+	defer func() {
+		if r := recover(); r != "init failed" {
+			t.Fatalf("want callback panic propagated, got %v", r)
+		}
+	}()
+	c.Load()
+}
@@ -0,0 +1,69 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// In 嵌入到 ProvideFunc 工厂的参数结构体中，标记该入参为参数结构体：
+// 容器不按类型查找该入参，而是新建结构体，按字段的 aware/value 标签逐个注入后传给工厂。
+// 字段规则同 Provide 的字段注入（按名称、omitempty、slice/map 批量注入、qualifier、Lazy[T]）。
+//
+//	type RepoParams struct {
+//		di.In
+//		Primary *sql.DB       `aware:"primaryDB"`
+//		Cache   *redis.Client `aware:",omitempty"`
+//		Table   string        `value:"repo.table"`
+//	}
+//
+//	func NewRepo(p RepoParams) *Repo { ... }
+//
+// 参数结构体必须以值类型作为入参。
+type In struct{}
+
+var inType = reflect.TypeFor[In]()
+
+// isParamStruct 判断类型是否为参数结构体（匿名嵌入了 di.In 的结构体）
+func isParamStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Anonymous && field.Type == inType {
+			return true
+		}
+	}
+	return false
+}
+
+// paramDefinitions 解析工厂入参中的参数结构体，返回 入参下标:字段定义（定义名为工厂 bean 名，错误归属该 bean）。
+// 参数结构体以指针传入、或对其声明 ArgQualifier 时返回 ErrDefinition。
+func (container *di) paramDefinitions(beanName string, ft reflect.Type, argQualifiers map[int][]string) (map[int]definition, error) {
+	var params map[int]definition
+	for i := 0; i < ft.NumIn(); i++ {
+		argType := ft.In(i)
+		if argType.Kind() == reflect.Pointer && isParamStruct(argType.Elem()) {
+			return nil, fmt.Errorf("%w: parameter struct %s must be passed by value for %s", ErrDefinition, argType.Elem().String(), ft.String())
+		}
+		if !isParamStruct(argType) {
+			continue
+		}
+		if _, ok := argQualifiers[i]; ok {
+			return nil, fmt.Errorf("%w: ArgQualifier not applicable to parameter struct %s, use qualifier tags on its fields", ErrDefinition, argType.String())
+		}
+		if params == nil {
+			params = map[int]definition{}
+		}
+		params[i] = container.newDefinition(beanName, argType)
+	}
+	return params, nil
+}
+
+// resolveParamStruct 创建参数结构体并注入字段，任一字段注入失败时返回 false（错误已通过 fail 上报）。
+// 注入的原型作用域实例登记到 owner 名下。
+func (container *di) resolveParamStruct(paramDef definition, owner any) (reflect.Value, bool) {
+	param := reflect.New(paramDef.Type).Elem()
+	valueOk := container.wireValue(param, paramDef, "")
+	awareOk := container.wireBean(param, paramDef, owner)
+	return param, valueOk && awareOk
+}
@@ -0,0 +1,112 @@
+package di
+
+import (
+	"errors"
+	"testing"
+)
+
+type paramDB struct{ dsn string }
+
+type paramCache struct{ _ int }
+
+type paramHandler interface{ Handle() string }
+
+type paramHandlerA struct{ _ int }
+
+func (*paramHandlerA) Handle() string { return "a" }
+
+type paramHandlerB struct{ _ int }
+
+func (*paramHandlerB) Handle() string { return "b" }
+
+type paramFastHandler struct{ _ int }
+
+func (*paramFastHandler) Handle() string       { return "fast" }
+func (*paramFastHandler) Qualifiers() []string { return []string{"fast"} }
+
+type paramRepo struct {
+	db       *paramDB
+	cache    *paramCache
+	handlers []paramHandler
+	table    string
+}
+
+type paramRepoParams struct {
+	In
+	DB       *paramDB       `aware:"replicaDB"`
+	Cache    *paramCache    `aware:",omitempty"`
+	Handlers []paramHandler `aware:""`
+	Table    string         `value:"repo.table"`
+}
+
+func newParamRepo(p paramRepoParams) *paramRepo {
+	return &paramRepo{db: p.DB, cache: p.Cache, handlers: p.Handlers, table: p.Table}
+}
+
+// TestParamStruct_Fields 参数结构体按字段标签注入：命名、omitempty、slice、value
+func TestParamStruct_Fields(t *testing.T) {
+	c := New()
+	c.RegisterNamedBean("primaryDB", &paramDB{dsn: "primary"})
+	c.RegisterNamedBean("replicaDB", &paramDB{dsn: "replica"})
+	c.RegisterBean(&paramHandlerA{})
+	c.RegisterBean(&paramHandlerB{})
+	c.SetProperty("repo.table", "users")
+	c.ProvideFunc(newParamRepo)
+	c.Load()
+
+	repo := MustGet[*paramRepo](c)
+	if repo.db.dsn != "replica" {
+		t.Fatalf("want named bean replicaDB, got %s", repo.db.dsn)
+	}
+	if repo.cache != nil {
+		t.Fatal("want omitempty field left nil")
+	}
+	if len(repo.handlers) != 2 {
+		t.Fatalf("want 2 handlers, got %d", len(repo.handlers))
+	}
+	if repo.table != "users" {
+		t.Fatalf("want value injected, got %q", repo.table)
+	}
+}
+
+type paramQualifiedParams struct {
+	In
+	Handler paramHandler `aware:",qualifier=fast"`
+}
+
+// TestParamStruct_QualifierAndMixedArgs 参数结构体与普通入参混用，字段支持限定标签
+func TestParamStruct_QualifierAndMixedArgs(t *testing.T) {
+	c := New()
+	c.RegisterBean(&paramDB{dsn: "main"})
+	c.RegisterBean(&paramHandlerA{})
+	c.RegisterBean(&paramFastHandler{})
+	c.RegisterBean(&paramHandlerB{})
+	var got string
+	c.ProvideFunc(func(db *paramDB, p paramQualifiedParams) *paramRepo {
+		got = db.dsn + "/" + p.Handler.Handle()
+		return &paramRepo{}
+	})
+	c.Load()
+
+	if got != "main/fast" {
+		t.Fatalf("want main/fast, got %q", got)
+	}
+}
+
+// TestParamStruct_Errors 缺失依赖归属工厂 bean；以指针传入参数结构体 Fatal
+func TestParamStruct_Errors(t *testing.T) {
+	c := New()
+	c.ProvideFunc(newParamRepo)
+	err := c.LoadE()
+	var beanErr *BeanError
+	if !errors.As(err, &beanErr) || beanErr.Bean != "paramRepo" || beanErr.Field != "DB" {
+		t.Fatalf("want missing field error on paramRepo.DB, got %v", err)
+	}
+
+	defer func() {
+		if r, _ := recover().(error); !errors.Is(r, ErrDefinition) {
+			t.Fatalf("want ErrDefinition, got %v", r)
+		}
+	}()
+	New().ProvideFunc(func(p *paramRepoParams) *paramRepo { return nil })
+}
@@ -0,0 +1,76 @@
+package di
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Phased 可被 bean 实现，声明 bean 所属的生命周期阶段：阶段值小的先触发 Initialized 与 Start，
+// 后触发 Stop 与 Destroy；未声明为 0。阶段内按生命周期顺序（注册顺序或 WithDependencyOrder）。
+// 注册选项 WithPhase 优先。
+type Phased interface {
+	Phase() int
+}
+
+// WithPhase 声明 bean 的生命周期阶段，覆盖 bean 实现的 Phased 接口
+func WithPhase(phase int) BeanOption {
+	return func(def *definition) {
+		def.phase = phase
+	}
+}
+
+// declaredPhase 读取实例类型实现的 Phased 接口声明的阶段，未实现时为 0
+func declaredPhase(instanceType reflect.Type) int {
+	if p, ok := zeroInstance(instanceType).(Phased); ok {
+		return p.Phase()
+	}
+	return 0
+}
+
+// phaseOf 返回 bean 的生命周期阶段：有定义的取定义上的值，直接注册的实例取其（原始实例的）Phased 接口。
+// 调用方（checkPhaseOrder、sortByPhase、initializedParallel 划分阶段）都在 worker 启动之前执行，直接读取各 map。
+func (container *di) phaseOf(beanName string) int {
+	if def, ok := container.beanDefinitionMap[beanName]; ok {
+		return def.phase
+	}
+	if bean, ok := container.beanMap[beanName]; ok {
+		if p, ok := container.originalOf(beanName, bean).(Phased); ok {
+			return p.Phase()
+		}
+	}
+	return 0
+}
+
+// checkPhaseOrder 检查依赖（aware 字段、工厂入参、装饰函数入参、DependsOn）是否都不在更后的阶段：
+// 按阶段排序后依赖方会先于被依赖的 bean 初始化、启动，无法同时满足两种顺序。
+// 返回合并错误（每条违反的依赖一个 *BeanError，包装 ErrDefinition）。
+func (container *di) checkPhaseOrder() error {
+	var errs []error
+	for _, name := range container.beanSort {
+		phase := container.phaseOf(name)
+		for _, depName := range container.lifecycleDeps(name) {
+			if depPhase := container.phaseOf(depName); depPhase > phase {
+				errs = append(errs, &BeanError{Bean: name, Err: fmt.Errorf("%w: %s(phase %d) depends on %s(phase %d) in a later phase",
+					ErrDefinition, name, phase, depName, depPhase)})
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return loadFailed(errs)
+	}
+	return nil
+}
+
+// sortByPhase 按生命周期阶段稳定排序，阶段内保持 order 原有的顺序（原地排序并返回 order）。
+func (container *di) sortByPhase(order []string) []string {
+	phases := make(map[string]int, len(order))
+	for _, name := range order {
+		phases[name] = container.phaseOf(name)
+	}
+	slices.SortStableFunc(order, func(a, b string) int {
+		return cmp.Compare(phases[a], phases[b])
+	})
+	return order
+}
@@ -0,0 +1,120 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// phBean 记录 Initialized/Start/Stop/Destroy
+type phBean struct{ name string }
+
+func (b *phBean) Initialized()                    { testRecorder.add("init:" + b.name) }
+func (b *phBean) Start(ctx context.Context) error { testRecorder.add("start:" + b.name); return nil }
+func (b *phBean) Stop(ctx context.Context) error  { testRecorder.add("stop:" + b.name); return nil }
+func (b *phBean) Destroy()                        { testRecorder.add("destroy:" + b.name) }
+
+type phInfra struct{ phBean }
+
+func (*phInfra) Phase() int { return -1 }
+
+type phServer struct {
+	phBean
+	App *phBean `aware:"app"`
+}
+
+// TestPhase 阶段小的先 Initialized/Start，后 Stop/Destroy；WithPhase 覆盖 Phased 接口
+func TestPhase(t *testing.T) {
+	testRecorder.reset()
+	c := New()
+	c.ProvideFunc(func() *phServer { return &phServer{phBean: phBean{name: "server"}} }, WithPhase(10))
+	c.RegisterNamedBean("app", &phBean{name: "app"})
+	c.RegisterNamedBean("metrics", &phBean{name: "metrics"})
+	c.RegisterBean(&phInfra{phBean{name: "infra"}})
+	c.RegisterNamedBean("cache", &phInfra{phBean{name: "cache"}})
+	c.Load()
+	if err := c.ServeE(cancelledContext()); err != nil {
+		t.Fatal(err)
+	}
+
+	for prefix, want := range map[string]string{
+		"init:":    "infra,cache,app,metrics,server",
+		"start:":   "infra,cache,app,metrics,server",
+		"stop:":    "server,metrics,app,cache,infra",
+		"destroy:": "server,metrics,app,cache,infra",
+	} {
+		if got := recorded(prefix); got != want {
+			t.Fatalf("want %s %s, got %s", prefix, want, got)
+		}
+	}
+}
+
+type phSlow struct {
+	release chan struct{}
+	done    atomic.Bool
+}
+
+func (*phSlow) Phase() int { return -1 }
+
+func (b *phSlow) Initialized() {
+	<-b.release
+	b.done.Store(true)
+}
+
+type phLate struct {
+	slow     *phSlow
+	sawEarly bool
+}
+
+func (b *phLate) Initialized() { b.sawEarly = b.slow.done.Load() }
+
+// TestPhase_Parallel 并行初始化时后一阶段等待前一阶段的 Initialized 全部返回
+func TestPhase_Parallel(t *testing.T) {
+	slow := &phSlow{release: make(chan struct{})}
+	late := &phLate{slow: slow}
+	c := New()
+	c.WithParallelInit(4)
+	c.RegisterBean(late)
+	c.RegisterBean(slow)
+	loaded := make(chan struct{})
+	go func() {
+		defer close(loaded)
+		c.Load()
+	}()
+	// 无阶段屏障时 phLate 会在放行前执行
+	time.Sleep(20 * time.Millisecond)
+	close(slow.release)
+	<-loaded
+	if !late.sawEarly {
+		t.Fatal("want later phase initialized after earlier phase drained")
+	}
+}
+
+type phEarly struct {
+	App *phBean `aware:"app"`
+}
+
+func (*phEarly) Phase() int { return -1 }
+
+// TestPhase_LaterDependency 依赖位于更后阶段的 bean 时 Load 失败，错误写明双方与阶段
+func TestPhase_LaterDependency(t *testing.T) {
+	c := New()
+	c.RegisterNamedBean("app", &phBean{name: "app"})
+	c.Provide(phEarly{})
+	err := c.LoadE()
+	var beanErr *BeanError
+	if !errors.Is(err, ErrLoadFailed) || !errors.Is(err, ErrDefinition) || !errors.As(err, &beanErr) || beanErr.Bean != "phEarly" ||
+		!strings.Contains(err.Error(), "phEarly(phase -1) depends on app(phase 0)") {
+		t.Fatalf("want later phase dependency error, got %v", err)
+	}
+
+	c = New()
+	c.RegisterNamedBean("app", &phBean{name: "app"})
+	c.Provide(phEarly{}, WithPhase(0))
+	if err := c.LoadE(); err != nil {
+		t.Fatalf("want dependency in the same phase allowed, got %v", err)
+	}
+}
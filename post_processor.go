@@ -0,0 +1,74 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// BeanPostProcessor 容器级的 bean 后置处理器，对容器创建的每个 bean（Provide/ProvideFunc 的单例、
+// 原型与请求作用域实例）生效：依赖注入完成后，BeforeInit 在 AfterPropertiesSet 之前、
+// AfterInit 在其之后调用。返回值替换当前 bean（不替换时原样返回），须为指针且仍可赋值给 bean 的类型；
+// 返回 error 时该 bean 装配失败。生命周期回调（AfterPropertiesSet、Initialized、Destroy）始终作用于原始实例。
+// 适合实现校验、自动登记、统一包装等横切逻辑。
+type BeanPostProcessor interface {
+	BeforeInit(beanName string, bean any) (any, error)
+	AfterInit(beanName string, bean any) (any, error)
+}
+
+// AddBeanPostProcessor 注册 bean 后置处理器，多个处理器按注册顺序调用。必须在 Load 前调用。
+func (container *di) AddBeanPostProcessor(processors ...BeanPostProcessor) DI {
+	if container.loaded.Load() {
+		container.log.Fatal(fmt.Errorf("%w", ErrLoaded))
+		return container
+	}
+	for _, p := range processors {
+		if p == nil {
+			continue
+		}
+		container.postProcessors = append(container.postProcessors, p)
+		container.log.Info(fmt.Sprintf("register bean post processor %T", p))
+	}
+	return container
+}
+
+// postProcess 依次调用后置处理器的 BeforeInit 或 AfterInit（before 为 true 时调用 BeforeInit），
+// 返回处理后的 bean，replaced 表示是否有处理器返回了另一个实例。失败时返回 nil（错误已通过 fail 上报）。
+func (container *di) postProcess(def definition, bean any, before bool) (_ any, replaced bool) {
+	hook := "AfterInit"
+	if before {
+		hook = "BeforeInit"
+	}
+	for _, p := range container.postProcessors {
+		container.log.Debug(fmt.Sprintf("call bean post processor %T.%s for %s(%T)", p, hook, def.Name, bean))
+		var (
+			result any
+			err    error
+		)
+		if before {
+			result, err = p.BeforeInit(def.Name, bean)
+		} else {
+			result, err = p.AfterInit(def.Name, bean)
+		}
+		if err != nil {
+			container.fail(def.Name, "", fmt.Errorf("%w: post processor %T.%s failed for %s: %w", ErrBean, p, hook, def.Name, err))
+			return nil, false
+		}
+		if result == nil {
+			container.fail(def.Name, "", fmt.Errorf("%w: post processor %T.%s returned nil for %s", ErrBean, p, hook, def.Name))
+			return nil, false
+		}
+		// 替换后的实例同样以指针身份跟踪（如 NewBean 的 finalizer）
+		if reflect.TypeOf(result).Kind() != reflect.Pointer {
+			container.fail(def.Name, "", fmt.Errorf("%w: post processor %T.%s returned non-pointer %T for %s", ErrBean, p, hook, result, def.Name))
+			return nil, false
+		}
+		if !reflect.TypeOf(result).AssignableTo(def.instanceType()) {
+			container.fail(def.Name, "", fmt.Errorf("%w: post processor %T.%s returned %T, not assignable to %s of %s",
+				ErrBean, p, hook, result, def.instanceType().String(), def.Name))
+			return nil, false
+		}
+		replaced = replaced || !sameInstance(result, bean)
+		bean = result
+	}
+	return bean, replaced
+}
@@ -0,0 +1,185 @@
+package di
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type bppService interface{ Call() string }
+
+type bppImpl struct{ _ int }
+
+func (s *bppImpl) Call() string        { return "impl" }
+func (s *bppImpl) AfterPropertiesSet() { testRecorder.add("after-props") }
+func (s *bppImpl) Initialized()        { testRecorder.add("initialized") }
+func (s *bppImpl) Destroy()            { testRecorder.add("destroy") }
+
+type bppDep struct{ _ int }
+
+type bppConsumer struct {
+	Service bppService `aware:""`
+}
+
+type bppWrapped struct{ inner bppService }
+
+func (w *bppWrapped) Call() string { return "wrapped(" + w.inner.Call() + ")" }
+
+// bppRecorder 记录调用顺序，并包装 bppService 类型的 bean
+type bppRecorder struct{ names []string }
+
+func (p *bppRecorder) BeforeInit(beanName string, bean any) (any, error) {
+	testRecorder.add("before:" + beanName)
+	return bean, nil
+}
+
+func (p *bppRecorder) AfterInit(beanName string, bean any) (any, error) {
+	testRecorder.add("after:" + beanName)
+	p.names = append(p.names, beanName)
+	if s, ok := bean.(bppService); ok {
+		return bppService(&bppWrapped{inner: s}), nil
+	}
+	return bean, nil
+}
+
+// TestPostProcessor_Order BeforeInit/AfterInit 包围 AfterPropertiesSet，返回值替换 bean，生命周期作用于原始实例
+func TestPostProcessor_Order(t *testing.T) {
+	testRecorder.reset()
+	p := &bppRecorder{}
+	c := New()
+	c.AddBeanPostProcessor(p)
+	// 依赖方先于被处理的 bean 注册，仍拿到处理后的实例
+	c.Provide(bppConsumer{})
+	c.ProvideFunc(func() bppService { return &bppImpl{} })
+	c.Provide(bppDep{})
+	c.RegisterBean(&decPrefix{})
+	c.Load()
+
+	if got := MustGet[*bppConsumer](c).Service.Call(); got != "wrapped(impl)" {
+		t.Fatalf("want processed bean injected, got %q", got)
+	}
+	if got := strings.Join(p.names, ","); got != "bppService,bppConsumer,bppDep" {
+		t.Fatalf("want every constructed bean processed (registered instances skipped), got %s", got)
+	}
+	c.destroyBeans()
+	events := strings.Join(testRecorder.events, ",")
+	if !strings.Contains(events, "before:bppService,after-props,after:bppService") {
+		t.Fatalf("want BeforeInit/AfterInit around AfterPropertiesSet, got %s", events)
+	}
+	if !strings.HasSuffix(events, "initialized,destroy") {
+		t.Fatalf("want lifecycle on original bean, got %s", events)
+	}
+}
+
+// TestPostProcessor_Prototype 原型作用域的每个实例都经过后置处理器
+func TestPostProcessor_Prototype(t *testing.T) {
+	p := &bppRecorder{}
+	c := New()
+	c.AddBeanPostProcessor(p)
+	c.ProvideFunc(func() bppService { return &bppImpl{} }, WithScope(ScopePrototype))
+	c.Load()
+
+	for i := 0; i < 2; i++ {
+		if got := MustGet[bppService](c).Call(); got != "wrapped(impl)" {
+			t.Fatalf("want processed prototype, got %q", got)
+		}
+	}
+	if len(p.names) != 2 {
+		t.Fatalf("want 2 processed prototypes, got %v", p.names)
+	}
+}
+
+var errInvalidBean = errors.New("invalid bean")
+
+type bppFailing struct{ bad string }
+
+func (p bppFailing) BeforeInit(beanName string, bean any) (any, error) {
+	if beanName == p.bad {
+		return nil, errInvalidBean
+	}
+	return bean, nil
+}
+
+func (p bppFailing) AfterInit(beanName string, bean any) (any, error) {
+	if beanName == "bppDep" && p.bad == "" {
+		return &bppConsumer{}, nil
+	}
+	return bean, nil
+}
+
+// TestPostProcessor_Cycle 字段循环依赖以原始实例注入；成环的 bean 随后被替换时报 ErrCircularDependency
+func TestPostProcessor_Cycle(t *testing.T) {
+	c := New()
+	c.AddBeanPostProcessor(bppFailing{bad: "none"})
+	c.Provide(decCycleA{})
+	c.Provide(decCycleB{})
+	c.Load()
+	if a := MustGet[*decCycleA](c); a.B.A != a {
+		t.Fatal("want pointer cycle injected")
+	}
+
+	c = New()
+	c.AddBeanPostProcessor(bppReplacing{})
+	c.Provide(decCycleA{})
+	c.Provide(decCycleB{})
+	if err := c.LoadE(); !errors.Is(err, ErrCircularDependency) {
+		t.Fatalf("want ErrCircularDependency, got %v", err)
+	}
+}
+
+// bppReplacing 以新实例替换 decCycleA
+type bppReplacing struct{}
+
+func (bppReplacing) BeforeInit(beanName string, bean any) (any, error) { return bean, nil }
+
+func (bppReplacing) AfterInit(beanName string, bean any) (any, error) {
+	if a, ok := bean.(*decCycleA); ok {
+		return &decCycleA{B: a.B}, nil
+	}
+	return bean, nil
+}
+
+// TestPostProcessor_Errors 处理器返回 error 或不匹配的类型时 bean 装配失败
+func TestPostProcessor_Errors(t *testing.T) {
+	c := New()
+	c.AddBeanPostProcessor(bppFailing{bad: "bppDep"})
+	c.Provide(bppDep{})
+	if err := c.LoadE(); !errors.Is(err, ErrBean) || !errors.Is(err, errInvalidBean) {
+		t.Fatalf("want ErrBean wrapping processor error, got %v", err)
+	}
+
+	c = New()
+	c.AddBeanPostProcessor(bppFailing{})
+	c.Provide(bppDep{})
+	if err := c.LoadE(); !errors.Is(err, ErrBean) || !strings.Contains(err.Error(), "not assignable") {
+		t.Fatalf("want ErrBean for mismatched replacement, got %v", err)
+	}
+}
+
+// bppSliceService 含 slice 的值类型（不可比较）
+type bppSliceService struct{ items []string }
+
+func (s bppSliceService) Call() string { return s.items[0] }
+
+// bppValueReplacing 以不可比较的值类型替换 bppService
+type bppValueReplacing struct{}
+
+func (bppValueReplacing) BeforeInit(beanName string, bean any) (any, error) { return bean, nil }
+
+func (bppValueReplacing) AfterInit(beanName string, bean any) (any, error) {
+	if _, ok := bean.(bppService); ok {
+		return bppSliceService{items: []string{"value"}}, nil
+	}
+	return bean, nil
+}
+
+// TestPostProcessor_NonPointer 以不可比较的值类型替换时装配失败，而不是在判断是否替换时 panic
+func TestPostProcessor_NonPointer(t *testing.T) {
+	c := New()
+	c.AddBeanPostProcessor(bppValueReplacing{})
+	c.ProvideFunc(func() bppService { return &bppImpl{} })
+	c.Provide(bppConsumer{})
+	if err := c.LoadE(); !errors.Is(err, ErrBean) || !strings.Contains(err.Error(), "non-pointer di.bppSliceService") {
+		t.Fatalf("want ErrBean for non-pointer replacement, got %v", err)
+	}
+}
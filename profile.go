@@ -0,0 +1,106 @@
+package di
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ProfilesActiveProperty 激活 profile 的配置项，值为逗号分隔的字符串或字符串列表；
+// 配合 AutoMigrateEnv 可由环境变量 DI_PROFILES_ACTIVE 设置。WithProfiles 优先。
+const ProfilesActiveProperty = "di.profiles.active"
+
+// DefaultProfile 未激活任何 profile 时生效的 profile
+const DefaultProfile = "default"
+
+// WithProfiles 设置激活的 profile，覆盖 di.profiles.active 配置项。必须在 Load 前调用。
+func (container *di) WithProfiles(profiles ...string) DI {
+	container.profiles = slices.Clone(profiles)
+	return container
+}
+
+// ActiveProfiles 返回激活的 profile：WithProfiles 设置的值，其次为 di.profiles.active 配置项，
+// 子容器都未设置时沿用父容器；均未设置时为 [DefaultProfile]。
+func (container *di) ActiveProfiles() []string {
+	if profiles := container.activeProfiles(); len(profiles) > 0 {
+		return profiles
+	}
+	return []string{DefaultProfile}
+}
+
+func (container *di) activeProfiles() []string {
+	if container.profiles != nil {
+		return slices.Clone(container.profiles)
+	}
+	if profiles := parseProfiles(container.valueStore.Get(ProfilesActiveProperty)); len(profiles) > 0 {
+		return profiles
+	}
+	if container.parent != nil {
+		return container.parent.activeProfiles()
+	}
+	return nil
+}
+
+// parseProfiles 解析 di.profiles.active 配置项：逗号分隔的字符串或列表
+func parseProfiles(value any) (profiles []string) {
+	var items []string
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		items = strings.Split(v, ",")
+	case []string:
+		items = v
+	case []any:
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+	default:
+		items = strings.Split(fmt.Sprint(v), ",")
+	}
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			profiles = append(profiles, item)
+		}
+	}
+	return profiles
+}
+
+// SetProfilePropertyMap 设置 profile 专属的配置项：该 profile 激活时，Load 开始前以 SetProperty 写入，
+// 覆盖同名的基础配置；多个激活的 profile 按激活顺序写入，靠后的优先。多次调用合并。
+func (container *di) SetProfilePropertyMap(profile string, properties map[string]any) DI {
+	if container.profileProperties[profile] == nil {
+		container.profileProperties[profile] = map[string]any{}
+	}
+	for key, value := range properties {
+		container.profileProperties[profile][key] = value
+	}
+	return container
+}
+
+// applyProfileProperties 在 Load 开始时把激活 profile 的专属配置写入 valueStore，覆盖同名的基础配置
+func (container *di) applyProfileProperties(profiles []string) {
+	for _, profile := range profiles {
+		if properties, ok := container.profileProperties[profile]; ok {
+			container.log.Info(fmt.Sprintf("apply %d properties of profile %s", len(properties), profile))
+			for key, value := range properties {
+				container.valueStore.Set(key, value)
+			}
+		}
+	}
+}
+
+// Profile 声明 bean 仅在指定 profile 激活时注册（任一匹配即可）；"!name" 表示 name 未激活时注册。
+// 与其他条件一样在 Load 时评估。
+func Profile(profiles ...string) BeanOption {
+	desc := fmt.Sprintf("profile %s", strings.Join(profiles, ","))
+	return withCondition(phaseEnv, desc, func(container *di, _ func(string) bool) bool {
+		active := container.ActiveProfiles()
+		return slices.ContainsFunc(profiles, func(profile string) bool {
+			if name, negate := strings.CutPrefix(profile, "!"); negate {
+				return !slices.Contains(active, name)
+			}
+			return slices.Contains(active, profile)
+		})
+	})
+}
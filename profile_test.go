@@ -0,0 +1,76 @@
+package di
+
+import (
+	"slices"
+	"testing"
+)
+
+type profileMailer struct {
+	Host string `value:"mail.host"`
+}
+
+type profileMockMailer struct{ _ int }
+
+type profileEUAudit struct{ _ int }
+
+// TestProfile_Beans 按激活的 profile 注册 bean
+func TestProfile_Beans(t *testing.T) {
+	c := New().WithProfiles("prod", "eu")
+	c.Provide(profileMailer{}, Profile("prod"))
+	c.Provide(profileMockMailer{}, Profile("!prod"))
+	c.Provide(profileEUAudit{}, Profile("eu", "us"))
+	c.Load()
+
+	if _, ok := c.GetBean("profileMailer"); !ok {
+		t.Fatal("expected prod bean registered")
+	}
+	if _, ok := c.GetBean("profileMockMailer"); ok {
+		t.Fatal("!prod bean should be skipped")
+	}
+	if _, ok := c.GetBean("profileEUAudit"); !ok {
+		t.Fatal("expected eu bean registered")
+	}
+}
+
+// TestProfile_Properties profile 专属配置覆盖基础配置，靠后的 profile 优先
+func TestProfile_Properties(t *testing.T) {
+	c := New()
+	c.SetProperty(ProfilesActiveProperty, "prod, eu")
+	c.SetPropertyMap(map[string]any{"mail.host": "localhost", "mail.port": 25})
+	c.SetProfilePropertyMap("prod", map[string]any{"mail.host": "smtp.prod"})
+	c.SetProfilePropertyMap("eu", map[string]any{"mail.host": "smtp.eu"})
+	c.SetProfilePropertyMap("dev", map[string]any{"mail.host": "smtp.dev"})
+	c.Provide(profileMailer{})
+	c.Load()
+
+	if got := c.ActiveProfiles(); !slices.Equal(got, []string{"prod", "eu"}) {
+		t.Fatalf("want profiles from property, got %v", got)
+	}
+	bean, _ := c.GetBean("profileMailer")
+	if host := bean.(*profileMailer).Host; host != "smtp.eu" {
+		t.Fatalf("want last active profile to win, got %s", host)
+	}
+	if c.GetProperty("mail.port") != 25 {
+		t.Fatal("want base properties kept")
+	}
+}
+
+// TestProfile_Default 未激活 profile 时为 default，子容器沿用父容器
+func TestProfile_Default(t *testing.T) {
+	c := New()
+	if got := c.ActiveProfiles(); !slices.Equal(got, []string{DefaultProfile}) {
+		t.Fatalf("want default profile, got %v", got)
+	}
+	c.Provide(profileMockMailer{}, Profile(DefaultProfile))
+	c.Load()
+	if _, ok := c.GetBean("profileMockMailer"); !ok {
+		t.Fatal("expected default profile bean registered")
+	}
+
+	parent := New().WithProfiles("prod")
+	parent.Load()
+	child := parent.NewChild()
+	if got := child.ActiveProfiles(); !slices.Equal(got, []string{"prod"}) {
+		t.Fatalf("want child to inherit parent profiles, got %v", got)
+	}
+}
@@ -2,6 +2,8 @@ package di
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -121,6 +123,59 @@ func TestProvideFunc_InvalidInput(t *testing.T) {
 	}, "must return a pointer")
 }
 
+type pfConn struct{ _ int }
+
+func (*pfConn) Destroy() { testRecorder.add("destroy-conn") }
+
+type pfPool struct{ _ int }
+
+var errPfDial = errors.New("dial failed")
+
+// TestProvideFunc_ErrorAndCleanup 工厂返回 error 与 cleanup
+func TestProvideFunc_ErrorAndCleanup(t *testing.T) {
+	testRecorder.reset()
+	c := New()
+	c.ProvideFunc(func() (*pfConn, func(), error) {
+		return &pfConn{}, func() { testRecorder.add("cleanup-conn") }, nil
+	})
+	c.ProvideFunc(func() (*pfPool, func(), error) {
+		return &pfPool{}, func() { testRecorder.add("cleanup-pool") }, nil
+	})
+	c.Load()
+	c.destroyBeans()
+
+	want := []string{"cleanup-pool", "destroy-conn", "cleanup-conn"}
+	if got := testRecorder.events; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func newPfFailingPool() (*pfPool, error) { return nil, errPfDial }
+
+// TestProvideFunc_FactoryError 工厂返回的 error 包装为 ErrBean 并包含工厂名；已创建 bean 的 cleanup 被执行
+func TestProvideFunc_FactoryError(t *testing.T) {
+	testRecorder.reset()
+	c := New()
+	c.ProvideFunc(func() (*pfConn, func(), error) {
+		return &pfConn{}, func() { testRecorder.add("cleanup-conn") }, nil
+	})
+	c.ProvideFunc(newPfFailingPool)
+	err := c.LoadE()
+	if !errors.Is(err, ErrBean) || !errors.Is(err, errPfDial) {
+		t.Fatalf("want ErrBean wrapping factory error, got %v", err)
+	}
+	if !contains(err.Error(), "newPfFailingPool") {
+		t.Fatalf("want factory name in error, got %q", err.Error())
+	}
+	if len(testRecorder.events) != 1 || testRecorder.events[0] != "cleanup-conn" {
+		t.Fatalf("want cleanup of created bean on load failure, got %v", testRecorder.events)
+	}
+
+	expectFatalErr(t, func() {
+		New().ProvideFunc(func() (*pfPool, *pfConn) { return nil, nil })
+	}, "second return value must be error")
+}
+
 // expectFatalErr 断言 fn 触发 Fatal panic（wrapping ErrBean）
 func expectFatalErr(t *testing.T, fn func(), substr string) {
 	t.Helper()
@@ -151,3 +206,26 @@ func contains(s, sub string) bool {
 	}
 	return false
 }
+
+type pfSession struct{ id int }
+
+// TestProvideFunc_PrototypeCleanup 同名原型的多个实例各自执行自己的 cleanup，且只执行一次
+func TestProvideFunc_PrototypeCleanup(t *testing.T) {
+	testRecorder.reset()
+	next := 0
+	c := New()
+	c.ProvideFunc(func() (*pfSession, func(), error) {
+		next++
+		s := &pfSession{id: next}
+		return s, func() { testRecorder.add(fmt.Sprintf("cleanup-session-%d", s.id)) }, nil
+	}, WithScope(ScopePrototype))
+	c.Load()
+	c.GetBean("pfSession")
+	c.GetBean("pfSession")
+	c.destroyBeans()
+	c.destroyBeans()
+
+	if got := strings.Join(testRecorder.events, ","); got != "cleanup-session-2,cleanup-session-1" {
+		t.Fatalf("want each instance cleaned up once in reverse order, got %s", got)
+	}
+}
@@ -0,0 +1,102 @@
+package di
+
+import (
+	"errors"
+	"testing"
+)
+
+type pnfStorage interface{ Path() string }
+
+type pnfFileStorage struct{ path string }
+
+func (s *pnfFileStorage) Path() string { return s.path }
+
+type pnfConsumer struct {
+	Storage pnfStorage `aware:""`
+}
+
+type pnfDB struct{ dsn string }
+
+type pnfRepo struct {
+	Primary *pnfDB `aware:"primaryDB"`
+	Replica *pnfDB `aware:"replicaDB"`
+}
+
+// TestProvideNamedFunc_InterfaceReturn 返回接口的工厂只以接口发布
+func TestProvideNamedFunc_InterfaceReturn(t *testing.T) {
+	c := New()
+	c.ProvideFunc(func() pnfStorage { return &pnfFileStorage{path: "/data"} })
+	c.Provide(pnfConsumer{})
+	c.Load()
+
+	if _, ok := c.GetBean("pnfStorage"); !ok {
+		t.Fatal("want bean named by interface type")
+	}
+	consumer, _ := c.GetBean("pnfConsumer")
+	if s := consumer.(*pnfConsumer).Storage; s == nil || s.Path() != "/data" {
+		t.Fatalf("want storage injected, got %v", s)
+	}
+	if _, ok := c.GetByType((*pnfStorage)(nil)); !ok {
+		t.Fatal("want bean found by interface")
+	}
+	if _, ok := c.GetByType((*pnfFileStorage)(nil)); ok {
+		t.Fatal("implementation type should not be published")
+	}
+	if c.HasBeanType((*pnfFileStorage)(nil)) {
+		t.Fatal("HasBeanType should not match implementation type")
+	}
+}
+
+// TestProvideNamedFunc_SameType 同一类型的多个工厂以不同名称共存
+func TestProvideNamedFunc_SameType(t *testing.T) {
+	c := New()
+	c.ProvideNamedFunc("primaryDB", func() *pnfDB { return &pnfDB{dsn: "primary"} })
+	c.ProvideNamedFunc("replicaDB", func() *pnfDB { return &pnfDB{dsn: "replica"} })
+	c.Provide(pnfRepo{})
+	c.Load()
+
+	repo, _ := c.GetBean("pnfRepo")
+	r := repo.(*pnfRepo)
+	if r.Primary.dsn != "primary" || r.Replica.dsn != "replica" {
+		t.Fatalf("want primary/replica, got %s/%s", r.Primary.dsn, r.Replica.dsn)
+	}
+	if all := c.GetByTypeAll((*pnfDB)(nil)); len(all) != 2 {
+		t.Fatalf("want 2 beans of same type, got %d", len(all))
+	}
+}
+
+// TestProvideNamedFunc_NilReturn 工厂返回 nil 时装配失败
+func TestProvideNamedFunc_NilReturn(t *testing.T) {
+	c := New()
+	c.ProvideFunc(func() pnfStorage { return nil })
+	err := c.LoadE()
+	if !errors.Is(err, ErrBean) || !contains(err.Error(), "returned nil") {
+		t.Fatalf("want ErrBean for nil factory result, got %v", err)
+	}
+
+	expectFatalErr(t, func() {
+		New().ProvideFunc(func() pnfDB { return pnfDB{} })
+	}, "must return a pointer or interface")
+}
+
+// pnfSliceStorage 值类型（含 slice，不可比较、不可哈希）实现接口
+type pnfSliceStorage struct{ items []string }
+
+func (s pnfSliceStorage) Path() string { return s.items[0] }
+
+// TestProvideNamedFunc_NonPointerReturn 接口返回的动态值不是指针时装配失败，而不是在跟踪实例时 panic
+func TestProvideNamedFunc_NonPointerReturn(t *testing.T) {
+	cleaned := false
+	c := New()
+	c.ProvideFunc(func() (pnfStorage, func(), error) {
+		return pnfSliceStorage{items: []string{"a"}}, func() { cleaned = true }, nil
+	})
+	c.Provide(pnfConsumer{})
+	err := c.LoadE()
+	if !errors.Is(err, ErrBean) || !contains(err.Error(), "non-pointer di.pnfSliceStorage") {
+		t.Fatalf("want ErrBean for non-pointer factory result, got %v", err)
+	}
+	if !cleaned {
+		t.Fatal("want cleanup of rejected result called")
+	}
+}
@@ -0,0 +1,87 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// BeanQualifiers 可被 bean 实现，声明自身的限定标签。
+// aware 字段以 `aware:",qualifier=x"` 要求标签时，只有具备全部标签的 bean 参与注入。
+// 注册选项 Qualifier 追加的标签与接口声明的合并。
+type BeanQualifiers interface {
+	Qualifiers() []string
+}
+
+// Qualifier 为 bean 追加限定标签（与 BeanQualifiers 接口声明的合并）
+func Qualifier(qualifiers ...string) BeanOption {
+	return func(def *definition) {
+		def.qualifiers = append(def.qualifiers, qualifiers...)
+	}
+}
+
+// ArgQualifier 要求 ProvideFunc 工厂第 index 个入参（从 0 开始）具备指定限定标签，
+// 该入参按类型查找并筛选，不再按类型推断的 beanName 查找。
+func ArgQualifier(index int, qualifiers ...string) BeanOption {
+	return func(def *definition) {
+		if def.argQualifiers == nil {
+			def.argQualifiers = map[int][]string{}
+		}
+		def.argQualifiers[index] = append(def.argQualifiers[index], qualifiers...)
+	}
+}
+
+// declaredQualifiers 读取实例类型实现的 BeanQualifiers 接口声明的标签
+func declaredQualifiers(instanceType reflect.Type) []string {
+	if q, ok := zeroInstance(instanceType).(BeanQualifiers); ok {
+		return slices.Clone(q.Qualifiers())
+	}
+	return nil
+}
+
+// qualifiersOf 返回 bean 的限定标签：有定义的取定义上的标签，直接注册的实例取其 BeanQualifiers 接口；
+// 本容器没有该 bean 时由父容器判断。
+func (container *di) qualifiersOf(beanName string, bean any) []string {
+	container.mu.RLock()
+	def, isDef := container.beanDefinitionMap[beanName]
+	_, isBean := container.beanMap[beanName]
+	container.mu.RUnlock()
+	switch {
+	case isDef:
+		return def.qualifiers
+	case isBean:
+		if q, ok := bean.(BeanQualifiers); ok {
+			return q.Qualifiers()
+		}
+		return nil
+	case container.parent != nil:
+		return container.parent.qualifiersOf(beanName, bean)
+	default:
+		return nil
+	}
+}
+
+// filterQualified 筛选具备全部 required 标签的候选；name 非空时同时要求 beanName 相同
+func (container *di) filterQualified(candidates []BeanWithName, name string, required []string) []BeanWithName {
+	return slices.DeleteFunc(candidates, func(c BeanWithName) bool {
+		if name != "" && c.Name != name {
+			return true
+		}
+		labels := container.qualifiersOf(c.Name, c.Bean)
+		for _, q := range required {
+			if !slices.Contains(labels, q) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// target 返回依赖的描述，用于日志与错误信息：有限定标签时为 类型(qualifier=...)
+func (a aware) target() string {
+	if len(a.Qualifiers) == 0 {
+		return a.Name
+	}
+	return fmt.Sprintf("%s(qualifier=%s)", a.Type.String(), strings.Join(a.Qualifiers, ","))
+}
@@ -0,0 +1,111 @@
+package di
+
+import (
+	"errors"
+	"testing"
+)
+
+type qualDB interface{ DSN() string }
+
+type qualPrimaryDB struct{ _ int }
+
+func (*qualPrimaryDB) DSN() string          { return "primary" }
+func (*qualPrimaryDB) Qualifiers() []string { return []string{"writable"} }
+
+type qualReplicaDB struct{ dsn string }
+
+func (d *qualReplicaDB) DSN() string { return d.dsn }
+
+type qualRepo struct {
+	Writer   qualDB            `aware:",qualifier=writable"`
+	Reader   qualDB            `aware:",qualifier=readonly,qualifier=eu"`
+	Readers  []qualDB          `aware:",qualifier=readonly"`
+	Replicas map[string]qualDB `aware:",qualifier=readonly"`
+	Missing  qualDB            `aware:",qualifier=archive,omitempty"`
+}
+
+type qualService struct {
+	DB qualDB
+}
+
+func newQualService(db qualDB) *qualService { return &qualService{DB: db} }
+
+// TestQualifier_Inject 单值、slice、map 字段按限定标签筛选
+func TestQualifier_Inject(t *testing.T) {
+	c := New()
+	c.Provide(qualPrimaryDB{})
+	c.RegisterNamedBean("replicaUS", &qualReplicaDB{dsn: "us"})
+	c.ProvideNamedBean("replicaEU", qualReplicaDB{}, Qualifier("readonly", "eu"))
+	c.Provide(qualRepo{})
+	c.Load()
+
+	bean, _ := c.GetBean("qualRepo")
+	repo := bean.(*qualRepo)
+	if repo.Writer.DSN() != "primary" {
+		t.Fatalf("want writable db, got %s", repo.Writer.DSN())
+	}
+	eu, _ := c.GetBean("replicaEU")
+	if repo.Reader != eu {
+		t.Fatal("want reader to match all qualifiers")
+	}
+	// 直接注册的实例没有 BeanQualifiers 接口，不具备标签
+	if len(repo.Readers) != 1 || len(repo.Replicas) != 1 || repo.Replicas["replicaEU"] == nil {
+		t.Fatalf("want only qualified beans collected, got %v %v", repo.Readers, repo.Replicas)
+	}
+	if repo.Missing != nil {
+		t.Fatal("want omitempty qualified field left empty")
+	}
+	if desc, _ := c.DescribeBean("qualPrimaryDB"); len(desc.Qualifiers) != 1 || desc.Qualifiers[0] != "writable" {
+		t.Fatalf("want declared qualifiers described, got %v", desc.Qualifiers)
+	}
+}
+
+// TestQualifier_FactoryArg 工厂入参按限定标签筛选
+func TestQualifier_FactoryArg(t *testing.T) {
+	c := New()
+	primary := &qualPrimaryDB{}
+	c.RegisterBean(primary)
+	c.RegisterBean(&qualReplicaDB{dsn: "us"})
+	c.ProvideFunc(newQualService, ArgQualifier(0, "writable"))
+	c.Load()
+
+	svc, _ := c.GetBean("qualService")
+	if svc.(*qualService).DB != primary {
+		t.Fatal("want factory arg resolved by qualifier")
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrDefinition) {
+			t.Fatalf("want ErrDefinition panic for bad index, got %v", err)
+		}
+	}()
+	New().ProvideFunc(newQualService, ArgQualifier(1, "writable"))
+}
+
+type qualStrict struct {
+	DB qualDB `aware:",qualifier=archive"`
+}
+
+// TestQualifier_NotFound 没有具备标签的 bean 时报 notfound
+func TestQualifier_NotFound(t *testing.T) {
+	c := New()
+	c.Provide(qualPrimaryDB{})
+	c.Provide(qualStrict{})
+	if err := c.LoadE(); !errors.Is(err, ErrBean) {
+		t.Fatalf("want ErrBean, got %v", err)
+	}
+}
+
+type qualBadTag struct {
+	DB qualDB `aware:",primary"`
+}
+
+// TestQualifier_UnknownOption 未知的 aware 标签选项报定义错误
+func TestQualifier_UnknownOption(t *testing.T) {
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrDefinition) {
+			t.Fatalf("want ErrDefinition panic, got %v", err)
+		}
+	}()
+	New().Provide(qualBadTag{})
+}
@@ -0,0 +1,117 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// requestScope 一个 context 对应的请求作用域视图
+type requestScope struct {
+	once  sync.Once
+	child *di
+	stop  func() bool // 取消 context.AfterFunc 注册
+}
+
+// Scope 返回 ctx 对应的请求作用域视图（同一 ctx 多次调用返回同一视图）。
+//
+// 视图是一个以本容器为父容器的子容器：ScopeRequest 作用域的定义在视图中各实例化一次，
+// 按常规 aware 规则注入（本地找不到的依赖回退到本容器的单例/原型 bean），走完整生命周期。
+// ctx 结束（cancel/超时）时视图内的 bean 倒序触发 Destroy；本容器销毁时仍活跃的视图也会先被销毁。
+//
+// 必须在 Load 之后调用，否则 Fatal（ErrNotLoaded）。单例/原型 bean 不能依赖请求作用域的 bean。
+func (container *di) Scope(ctx context.Context) DI {
+	if !container.loaded.Load() {
+		container.log.Fatal(fmt.Errorf("%w: request scope requires a loaded container", ErrNotLoaded))
+		return nil
+	}
+	container.mu.Lock()
+	scope, exist := container.scopes[ctx]
+	if !exist {
+		scope = &requestScope{child: container.newRequestScope(ctx)}
+		container.scopes[ctx] = scope
+	}
+	container.mu.Unlock()
+
+	// 视图的 Load 含生命周期回调，在锁外执行；并发的同 ctx 调用等待首次加载完成
+	scope.once.Do(func() {
+		defer func() {
+			// 视图加载失败：移除，后续同 ctx 调用不会拿到半初始化的视图
+			if r := recover(); r != nil {
+				container.mu.Lock()
+				delete(container.scopes, ctx)
+				container.mu.Unlock()
+				panic(r)
+			}
+		}()
+		container.log.Info(fmt.Sprintf("open request scope with %d bean(s)", len(scope.child.beanSort)))
+		scope.child.Load()
+		scope.stop = context.AfterFunc(ctx, func() {
+			container.closeScope(ctx)
+		})
+	})
+	return scope.child
+}
+
+// newRequestScope 创建请求作用域视图：复制 ScopeRequest 定义（在视图内按单例处理），
+// 共享本容器的日志、配置、选择策略、装饰函数、后置处理器、生命周期顺序、并行初始化与启停超时设置。
+func (container *di) newRequestScope(ctx context.Context) *di {
+	child := New()
+	child.parent = container
+	child.log = container.log
+	child.unsafe = container.unsafe
+	child.valueStore = container.valueStore
+	child.selector = container.selector
+	child.decorators = container.decorators
+	child.postProcessors = container.postProcessors
+	child.dependencyOrder = container.dependencyOrder
+	child.workers = container.workers
+	child.startTimeout = container.startTimeout
+	child.stopTimeout = container.stopTimeout
+	child.ctx = ctx
+	for _, name := range container.beanSort {
+		if def, ok := container.beanDefinitionMap[name]; ok && def.scope == ScopeRequest {
+			def.scope = ScopeSingleton
+			child.beanDefinitionMap[name] = def
+			child.beanSort = append(child.beanSort, name)
+		}
+	}
+	return child
+}
+
+// closeScope 移除并销毁 ctx 对应的请求作用域视图
+func (container *di) closeScope(ctx context.Context) {
+	container.mu.Lock()
+	scope, ok := container.scopes[ctx]
+	delete(container.scopes, ctx)
+	container.mu.Unlock()
+	if !ok {
+		return
+	}
+	container.log.Info("close request scope")
+	scope.child.shutdownScope()
+}
+
+// shutdownScope 停止并销毁请求作用域视图的 bean；Stop 的错误只打印 warn 日志（已在 stopBeans 中打印）
+func (container *di) shutdownScope() {
+	container.stopBeans(container.ctx)
+	container.destroyBeans()
+}
+
+// destroyScopes 销毁所有仍然活跃的请求作用域视图（容器销毁时调用）
+func (container *di) destroyScopes() {
+	container.mu.Lock()
+	scopes := slices.Collect(maps.Values(container.scopes))
+	clear(container.scopes)
+	container.mu.Unlock()
+	for _, scope := range scopes {
+		// 等待可能仍在进行的首次加载
+		scope.once.Do(func() {})
+		if scope.stop != nil {
+			scope.stop()
+		}
+		scope.child.shutdownScope()
+	}
+}
@@ -0,0 +1,113 @@
+package di
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type rsTenantRepo struct{}
+
+// rsTenant 请求作用域 bean，依赖容器单例
+type rsTenant struct {
+	Repo *rsTenantRepo `aware:""`
+	ID   int
+}
+
+// rsHandler 请求作用域 bean，依赖同一作用域的 rsTenant
+type rsHandler struct {
+	Tenant *rsTenant `aware:""`
+}
+
+// TestRequestScope_PerContext 同一 ctx 共享实例，不同 ctx 各自创建
+func TestRequestScope_PerContext(t *testing.T) {
+	c := New()
+	c.RegisterBean(&rsTenantRepo{})
+	c.Provide(rsTenant{}, WithScope(ScopeRequest))
+	c.Provide(rsHandler{}, WithScope(ScopeRequest))
+	c.Load()
+
+	if _, ok := c.GetBean("rsTenant"); ok {
+		t.Fatal("request-scoped bean should not be created by container Load")
+	}
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	s1 := c.Scope(ctx1)
+	if s1 != c.Scope(ctx1) {
+		t.Fatal("want same view for same context")
+	}
+	t1, ok := s1.GetBean("rsTenant")
+	if !ok {
+		t.Fatal("expected rsTenant in scope")
+	}
+	if t1.(*rsTenant).Repo == nil {
+		t.Fatal("expected singleton injected into request-scoped bean")
+	}
+	h1, _ := s1.GetBean("rsHandler")
+	if h1.(*rsHandler).Tenant != t1 {
+		t.Fatal("want request beans in the same scope to share instances")
+	}
+	// 视图可解析容器单例
+	if _, ok := s1.GetBean("rsTenantRepo"); !ok {
+		t.Fatal("expected singleton visible through scope view")
+	}
+	if s1.Context() != ctx1 {
+		t.Fatal("want view context to be request context")
+	}
+
+	t2, _ := c.Scope(ctx2).GetBean("rsTenant")
+	if t2 == t1 {
+		t.Fatal("want distinct instances per context")
+	}
+}
+
+// rsSession 销毁时通知测试（销毁在 context.AfterFunc 的 goroutine 中执行）
+type rsSession struct{}
+
+var rsSessionDestroyed = make(chan struct{}, 1)
+
+func (*rsSession) Destroy() { rsSessionDestroyed <- struct{}{} }
+
+// TestRequestScope_DestroyOnCancel ctx 结束时销毁视图内的 bean
+func TestRequestScope_DestroyOnCancel(t *testing.T) {
+	c := New()
+	c.Provide(rsSession{}, WithScope(ScopeRequest))
+	c.Load()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, ok := c.Scope(ctx).GetBean("rsSession"); !ok {
+		t.Fatal("expected rsSession in scope")
+	}
+	cancel()
+	select {
+	case <-rsSessionDestroyed:
+	case <-time.After(time.Second):
+		t.Fatal("want request-scoped bean destroyed after context cancel")
+	}
+}
+
+// rsAudit 记录生命周期（仅用于不会被 cancel 的 context，避免异步销毁与断言竞争）
+type rsAudit struct{}
+
+func (*rsAudit) Initialized() { testRecorder.add("audit-initialized") }
+func (*rsAudit) Destroy()     { testRecorder.add("audit-destroy") }
+
+// TestRequestScope_DestroyWithContainer 容器销毁时活跃视图一并销毁
+func TestRequestScope_DestroyWithContainer(t *testing.T) {
+	testRecorder.reset()
+	c := New()
+	c.Provide(rsAudit{}, WithScope(ScopeRequest))
+	c.Load()
+	c.Scope(context.Background())
+
+	c.destroyBeans()
+	want := []string{"audit-initialized", "audit-destroy"}
+	got := testRecorder.events
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
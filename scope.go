@@ -0,0 +1,156 @@
+package di
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Scope bean 作用域
+type Scope int
+
+const (
+	// ScopeSingleton 单例（默认）：Load 时创建，容器内唯一实例
+	ScopeSingleton Scope = iota
+	// ScopePrototype 原型：每个 aware 注入点、每次 GetBean/GetByType 都会得到一个新的、完整注入的实例
+	ScopePrototype
+	// ScopeRequest 请求作用域：不在容器 Load 时创建，由 Scope(ctx) 返回的视图按 context 各创建一次，
+	// context 结束时销毁
+	ScopeRequest
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeSingleton:
+		return "singleton"
+	case ScopePrototype:
+		return "prototype"
+	case ScopeRequest:
+		return "request"
+	default:
+		return fmt.Sprintf("Scope(%d)", int(s))
+	}
+}
+
+// BeanScope 可被 bean 实现，声明自身作用域。注册选项 WithScope 优先。
+type BeanScope interface {
+	Scope() Scope
+}
+
+// pendingOwner 工厂调用前的临时 owner：工厂入参中的原型实例先登记在它名下，
+// 工厂返回后再转移给产物（产物创建前无法作为 map key）。
+type pendingOwner struct {
+	name string
+}
+
+// newPrototypeBean 按原型作用域的定义创建一个新实例，走完整生命周期
+// （实例化 → BeanConstruct → 注入 → AfterPropertiesSet → Initialized），
+// 并登记到 owner 名下：owner 销毁时（destroyBean）倒序销毁其名下的原型实例。
+// owner 为 nil 表示归容器所有（GetBean/GetByType 获取），容器销毁时销毁。
+// 失败时返回 nil（错误已通过 fail 上报）。
+func (container *di) newPrototypeBean(def definition, owner any) any {
+	container.log.Info(fmt.Sprintf("new prototype bean instance %s", def.Name))
+	prototype := container.instanceBean(def)
+	if prototype == nil {
+		return nil
+	}
+	container.constructBean(def.Name, prototype)
+	bean, _ := container.processBean(prototype, def, prototype)
+	container.initializedBean(def.Name, prototype)
+	// 登记原始实例（销毁作用于原始实例），返回后置处理与装饰后的实例
+	container.trackDependent(owner, def.Name, prototype)
+	bean, _ = container.decorateBean(def.Name, bean, prototype)
+	return bean
+}
+
+// newDeferredBean 为按需创建的定义取得实例：原型作用域每次新建，WithLazyInit 的单例首次创建
+func (container *di) newDeferredBean(def definition, owner any) any {
+	if def.scope == ScopePrototype {
+		return container.newPrototypeBean(def, owner)
+	}
+	return container.lazyInitBean(def)
+}
+
+// deferredDefinition 返回 beanName 对应的按需创建定义（尚未创建的延迟单例或原型作用域）
+func (container *di) deferredDefinition(beanName string) (def definition, ok bool) {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+	if _, created := container.beanMap[beanName]; created {
+		return def, false
+	}
+	def, ok = container.beanDefinitionMap[beanName]
+	return def, ok && def.deferred()
+}
+
+// materialize 将按类型查找得到的候选转为可注入的实例：
+// 原型作用域与尚未创建的延迟单例的候选为占位实例（仅用于类型匹配与选择策略），此时创建真正的实例；
+// Load 期间待装饰的单例此时完成装饰。
+// 候选来自父容器时由父容器创建。
+func (container *di) materialize(candidate BeanWithName, owner any) any {
+	if def, ok := container.deferredDefinition(candidate.Name); ok {
+		return container.newDeferredBean(def, owner)
+	}
+	if container.pendingCompletion(candidate.Name) {
+		bean, _ := container.completeBean(candidate.Name)
+		return bean
+	}
+	if container.parent != nil && !container.hasLocal(candidate.Name) {
+		return container.parent.materialize(candidate, container.parentOwner(owner))
+	}
+	return candidate.Bean
+}
+
+// hasLocal 判断 beanName 是否为本容器的实例或定义
+func (container *di) hasLocal(beanName string) bool {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+	_, isBean := container.beanMap[beanName]
+	_, isDef := container.beanDefinitionMap[beanName]
+	return isBean || isDef
+}
+
+// parentOwner 返回在父容器中登记原型实例时使用的 owner：
+// 归本容器所有（nil）的实例在父容器中登记在本容器名下，本容器销毁时一并销毁。
+func (container *di) parentOwner(owner any) any {
+	if owner == nil {
+		return container
+	}
+	return owner
+}
+
+// trackDependent 将原型实例登记到 owner 名下
+func (container *di) trackDependent(owner any, beanName string, bean any) {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	container.dependents[owner] = append(container.dependents[owner], BeanWithName{Name: beanName, Bean: bean})
+}
+
+// transferDependents 将 from 名下的原型实例转移到 to 名下
+func (container *di) transferDependents(from, to any) {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	if deps, ok := container.dependents[from]; ok {
+		delete(container.dependents, from)
+		container.dependents[to] = append(container.dependents[to], deps...)
+	}
+}
+
+// destroyDependents 按创建倒序销毁 owner 名下的原型实例（锁内摘除，锁外回调）。
+// 本容器 bean 可能注入了父容器的原型实例，非 nil owner 会继续在父容器中查找。
+func (container *di) destroyDependents(owner any) {
+	container.mu.Lock()
+	deps := container.dependents[owner]
+	delete(container.dependents, owner)
+	container.mu.Unlock()
+	for _, dep := range slices.Backward(deps) {
+		container.destroyBean(dep.Name, dep.Bean)
+	}
+	if owner != nil && container.parent != nil {
+		container.parent.destroyDependents(owner)
+	}
+}
+
+// placeholder 返回按需创建定义的占位实例（零值），供按类型查找时做类型匹配与选择策略判断；
+// 返回接口类型的工厂定义无法构造占位实例，为 nil
+func (def definition) placeholder() any {
+	return zeroInstance(def.instanceType())
+}
@@ -0,0 +1,178 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// 原型作用域测试用 bean：每个实例有独立编号
+var scopeSeq int
+
+type scopeCounter struct {
+	ID int
+}
+
+func (s *scopeCounter) BeanConstruct() {
+	scopeSeq++
+	s.ID = scopeSeq
+}
+
+func (s *scopeCounter) Destroy() {
+	testRecorder.add("destroy-counter")
+}
+
+type scopeOwnerA struct {
+	Counter *scopeCounter `aware:""`
+}
+
+func (*scopeOwnerA) Destroy() { testRecorder.add("destroy-owner-a") }
+
+type scopeOwnerB struct {
+	Counter *scopeCounter `aware:""`
+}
+
+// TestScope_PrototypePerInjection 每个注入点得到新实例
+func TestScope_PrototypePerInjection(t *testing.T) {
+	scopeSeq = 0
+	c := New()
+	c.Provide(scopeCounter{}, WithScope(ScopePrototype))
+	c.Provide(scopeOwnerA{})
+	c.Provide(scopeOwnerB{})
+	c.Load()
+
+	a, _ := c.GetBean("scopeOwnerA")
+	b, _ := c.GetBean("scopeOwnerB")
+	ca, cb := a.(*scopeOwnerA).Counter, b.(*scopeOwnerB).Counter
+	if ca == nil || cb == nil {
+		t.Fatal("expected prototype injected")
+	}
+	if ca == cb {
+		t.Fatal("want distinct prototype instances per injection point")
+	}
+	if ca.ID == 0 || cb.ID == 0 {
+		t.Fatal("expected BeanConstruct called on prototype instances")
+	}
+}
+
+// TestScope_PrototypeGetBean 每次 GetBean/GetByType 返回新实例
+func TestScope_PrototypeGetBean(t *testing.T) {
+	c := New()
+	c.Provide(scopeCounter{}, WithScope(ScopePrototype))
+	c.Load()
+
+	first, ok1 := c.GetBean("scopeCounter")
+	second, ok2 := c.GetBean("scopeCounter")
+	if !ok1 || !ok2 {
+		t.Fatal("expected prototype bean found")
+	}
+	if first == second {
+		t.Fatal("want new instance per GetBean")
+	}
+	byType, ok := c.GetByType(&scopeCounter{})
+	if !ok || byType == first || byType == second {
+		t.Fatal("want new instance per GetByType")
+	}
+	if desc, _ := c.DescribeBean("scopeCounter"); desc.Scope != ScopePrototype {
+		t.Fatalf("want prototype scope in description, got %s", desc.Scope)
+	}
+}
+
+// TestScope_PrototypeDestroy owner 销毁时紧接着销毁其原型依赖，GetBean 创建的实例随容器销毁
+func TestScope_PrototypeDestroy(t *testing.T) {
+	testRecorder.reset()
+	c := New()
+	c.Provide(scopeCounter{}, WithScope(ScopePrototype))
+	c.Provide(scopeOwnerA{})
+	c.Load()
+	c.GetBean("scopeCounter")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.Serve(ctx)
+
+	want := []string{"destroy-owner-a", "destroy-counter", "destroy-counter"}
+	got := testRecorder.events
+	if len(got) != len(want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+	}
+}
+
+// 通过接口声明作用域
+type scopeDeclared struct{ _ int }
+
+func (*scopeDeclared) Scope() Scope { return ScopePrototype }
+
+type scopeIface interface{ Scope() Scope }
+
+type scopeIfaceOwner struct {
+	Items []scopeIface `aware:""`
+	One   scopeIface   `aware:""`
+}
+
+// TestScope_DeclaredByInterface BeanScope 接口声明 + 接口/slice 注入
+func TestScope_DeclaredByInterface(t *testing.T) {
+	c := New()
+	c.Provide(scopeDeclared{})
+	c.Provide(scopeIfaceOwner{})
+	c.Load()
+
+	bean, _ := c.GetBean("scopeIfaceOwner")
+	owner := bean.(*scopeIfaceOwner)
+	if len(owner.Items) != 1 || owner.One == nil {
+		t.Fatalf("expected prototype injected into slice and interface field, got %+v", owner)
+	}
+	if owner.Items[0] == owner.One {
+		t.Fatal("want distinct instances for slice and interface field")
+	}
+}
+
+// 原型之间成环无法满足
+type scopeLoopA struct {
+	B *scopeLoopB `aware:""`
+}
+type scopeLoopB struct {
+	A *scopeLoopA `aware:""`
+}
+
+func TestScope_PrototypeCycle(t *testing.T) {
+	c := New()
+	c.Provide(scopeLoopA{}, WithScope(ScopePrototype))
+	c.Provide(scopeLoopB{}, WithScope(ScopePrototype))
+	if err := c.LoadE(); !errors.Is(err, ErrCircularDependency) {
+		t.Fatalf("want ErrCircularDependency, got %v", err)
+	}
+}
+
+// 单例与原型成环可正常注入
+func TestScope_SingletonPrototypeCycle(t *testing.T) {
+	c := New()
+	c.Provide(scopeLoopA{})
+	c.Provide(scopeLoopB{}, WithScope(ScopePrototype))
+	c.Load()
+	a, _ := c.GetBean("scopeLoopA")
+	if a.(*scopeLoopA).B.A != a {
+		t.Fatal("expected prototype wired with singleton")
+	}
+}
+
+// TestScope_PrototypeFactory 工厂 bean 也可声明原型作用域
+func TestScope_PrototypeFactory(t *testing.T) {
+	c := New()
+	calls := 0
+	c.ProvideFunc(func() *scopeCounter {
+		calls++
+		return &scopeCounter{}
+	}, WithScope(ScopePrototype))
+	c.Provide(scopeOwnerA{})
+	c.Provide(scopeOwnerB{})
+	c.Load()
+	if calls != 2 {
+		t.Fatalf("want factory called per injection point, got %d", calls)
+	}
+}
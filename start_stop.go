@@ -0,0 +1,167 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	ErrStart = errors.New("bean start failed")
+	ErrStop  = errors.New("bean stop failed")
+)
+
+// WithStartTimeout 设置 Startable.Start 的超时：perBean 为单个 bean 的超时，total 为整个启动阶段的超时，0 表示不限。
+// 超时的 Start 视为失败（错误包装 context.DeadlineExceeded），容器不再等待其返回；Start 应响应 ctx 的取消，
+// 忽略取消、超时后才启动成功的 bean 会在后台被 Stop。必须在 Load 前调用。
+func (container *di) WithStartTimeout(perBean, total time.Duration) DI {
+	container.startTimeout = lifecycleTimeout{perBean: perBean, total: total}
+	return container
+}
+
+// WithStopTimeout 设置 Stoppable.Stop 的超时（含启动失败时的回滚），规则同 WithStartTimeout。
+func (container *di) WithStopTimeout(perBean, total time.Duration) DI {
+	container.stopTimeout = lifecycleTimeout{perBean: perBean, total: total}
+	return container
+}
+
+// lifecycleTimeout Start/Stop 阶段的超时设置，0 表示不限
+type lifecycleTimeout struct {
+	perBean time.Duration
+	total   time.Duration
+}
+
+// startBeans 按生命周期顺序启动 Load 完成时已创建的单例（被装饰的 bean 作用于原始实例）。
+// 未实现 Startable 的 bean 视为直接启动成功。某个 Start 失败或超时时，倒序 Stop 已启动的 bean 后
+// 返回合并错误（ErrLoadFailed 摘要、启动错误与回滚中的停止错误）。
+func (container *di) startBeans() error {
+	ctx, cancel := container.startTimeout.phase(context.Background())
+	defer cancel()
+	for _, name := range container.order {
+		container.mu.RLock()
+		bean, ok := container.beanMap[name]
+		container.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		bean = container.originalOf(name, bean)
+		if s, ok := bean.(Startable); ok {
+			container.log.Info(fmt.Sprintf("start bean %s(%T)", name, bean))
+			err := container.startTimeout.call(ctx, s.Start, func(err error) {
+				if err == nil {
+					container.stopAbandoned(name, bean)
+				}
+			})
+			if err != nil {
+				container.log.Warn(fmt.Sprintf("start bean %s(%T) failed, stopping started beans: %s", name, bean, err.Error()))
+				errs := []error{&BeanError{Bean: name, Err: fmt.Errorf("%w: %s(%T): %w", ErrStart, name, bean, err)}}
+				errs = append(errs, container.stopBeans(context.Background())...)
+				return loadFailed(errs)
+			}
+		}
+		container.mu.Lock()
+		container.started = append(container.started, BeanWithName{Name: name, Bean: bean})
+		container.mu.Unlock()
+	}
+	return nil
+}
+
+// stopBeans 按启动倒序停止已启动的 bean，单个失败不影响其余 bean，返回各 bean 的停止错误（*BeanError，包装 ErrStop）。
+// ctx 的取消不影响停止（Serve 退出时 ctx 已取消），仅保留其中的值。每个 bean 只停止一次。
+func (container *di) stopBeans(ctx context.Context) (errs []error) {
+	container.mu.Lock()
+	started := container.started
+	container.started = nil
+	container.mu.Unlock()
+
+	ctx, cancel := container.stopTimeout.phase(context.WithoutCancel(ctx))
+	defer cancel()
+	for _, b := range slices.Backward(started) {
+		s, ok := b.Bean.(Stoppable)
+		if !ok {
+			continue
+		}
+		container.log.Info(fmt.Sprintf("stop bean %s(%T)", b.Name, b.Bean))
+		if err := container.stopTimeout.call(ctx, s.Stop, nil); err != nil {
+			container.log.Warn(fmt.Sprintf("stop bean %s(%T) failed: %s", b.Name, b.Bean, err.Error()))
+			errs = append(errs, &BeanError{Bean: b.Name, Err: fmt.Errorf("%w: %s(%T): %w", ErrStop, b.Name, b.Bean, err)})
+		}
+	}
+	return errs
+}
+
+// stopAbandoned 停止超时后才启动成功的 bean：容器已把它视为启动失败，不会在回滚或 Serve 退出时停止它。
+// 在放弃等待的 goroutine 中调用，Stop 的错误与 panic 只打印 warn 日志。
+func (container *di) stopAbandoned(beanName string, bean any) {
+	container.log.Warn(fmt.Sprintf("bean %s(%T) started after start timeout, stopping it", beanName, bean))
+	s, ok := bean.(Stoppable)
+	if !ok {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			container.log.Warn(fmt.Sprintf("stop bean %s(%T) panicked: %v", beanName, bean, r))
+		}
+	}()
+	ctx, cancel := container.stopTimeout.phase(context.Background())
+	defer cancel()
+	if err := container.stopTimeout.call(ctx, s.Stop, nil); err != nil {
+		container.log.Warn(fmt.Sprintf("stop bean %s(%T) failed: %s", beanName, bean, err.Error()))
+	}
+}
+
+// phase 返回整个阶段的 ctx（total > 0 时带超时）
+func (t lifecycleTimeout) phase(parent context.Context) (context.Context, context.CancelFunc) {
+	if t.total > 0 {
+		return context.WithTimeout(parent, t.total)
+	}
+	return context.WithCancel(parent)
+}
+
+// call 调用 Start/Stop：perBean > 0 时为其设置超时。ctx 可能到期时在独立 goroutine 中调用，
+// 到期未返回则不再等待，返回 ctx 的错误；回调 panic 在调用方重新抛出。
+// late 非 nil 时，放弃等待的回调最终返回后以其结果调用 late（回调 panic 时不调用）。
+func (t lifecycleTimeout) call(ctx context.Context, fn func(context.Context) error, late func(error)) error {
+	if t.perBean > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.perBean)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		return fn(ctx)
+	}
+	type result struct {
+		err      error
+		panicked any
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- result{panicked: r}
+			}
+		}()
+		done <- result{err: fn(ctx)}
+	}()
+	select {
+	case r := <-done:
+		if r.panicked != nil {
+			panic(r.panicked)
+		}
+		return r.err
+	case <-ctx.Done():
+		if late != nil {
+			go func() {
+				if r := <-done; r.panicked == nil {
+					late(r.err)
+				}
+			}()
+		}
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,127 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ssBean 记录 Start/Stop/Destroy，按配置返回错误
+type ssBean struct {
+	name     string
+	startErr error
+	stopErr  error
+}
+
+func (b *ssBean) Start(ctx context.Context) error {
+	testRecorder.add("start:" + b.name)
+	return b.startErr
+}
+
+func (b *ssBean) Stop(ctx context.Context) error {
+	testRecorder.add("stop:" + b.name)
+	return b.stopErr
+}
+
+func (b *ssBean) Destroy() { testRecorder.add("destroy:" + b.name) }
+
+var errStopFailed = errors.New("flush failed")
+
+func cancelledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+// TestStartStop Load 按顺序启动，ServeE 倒序停止后销毁，并合并返回停止错误
+func TestStartStop(t *testing.T) {
+	testRecorder.reset()
+	c := New()
+	c.RegisterNamedBean("a", &ssBean{name: "a", stopErr: errStopFailed})
+	c.RegisterNamedBean("b", &ssBean{name: "b"})
+	c.RegisterNamedBean("c", &ssBean{name: "c", stopErr: errStopFailed})
+	c.Load()
+
+	err := c.ServeE(cancelledContext())
+	if !errors.Is(err, ErrStop) || !errors.Is(err, errStopFailed) {
+		t.Fatalf("want aggregated stop error, got %v", err)
+	}
+	var beanErr *BeanError
+	if !errors.As(err, &beanErr) || beanErr.Bean != "c" || strings.Count(err.Error(), "flush failed") != 2 {
+		t.Fatalf("want stop errors of c and a, got %v", err)
+	}
+	want := "start:a,start:b,start:c,stop:c,stop:b,stop:a,destroy:c,destroy:b,destroy:a"
+	if got := strings.Join(testRecorder.events, ","); got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+}
+
+// TestStartStop_Rollback Start 失败时倒序停止已启动的 bean，销毁后容器进入失败状态
+func TestStartStop_Rollback(t *testing.T) {
+	testRecorder.reset()
+	errStart := errors.New("port in use")
+	c := New()
+	c.RegisterNamedBean("a", &ssBean{name: "a"})
+	c.RegisterNamedBean("b", &ssBean{name: "b", startErr: errStart})
+	c.RegisterNamedBean("c", &ssBean{name: "c"})
+	err := c.LoadE()
+	if !errors.Is(err, ErrLoadFailed) || !errors.Is(err, ErrStart) || !errors.Is(err, errStart) {
+		t.Fatalf("want start failure, got %v", err)
+	}
+	want := "start:a,start:b,stop:a,destroy:c,destroy:b,destroy:a"
+	if got := strings.Join(testRecorder.events, ","); got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+	if serveErr := c.ServeE(cancelledContext()); serveErr != err {
+		t.Fatalf("want ServeE to return load failure, got %v", serveErr)
+	}
+}
+
+// ssLate Start 忽略 ctx，超时之后才启动成功
+type ssLate struct {
+	release chan struct{}
+	stopped chan struct{}
+}
+
+func (b *ssLate) Start(ctx context.Context) error {
+	<-b.release
+	return nil
+}
+
+func (b *ssLate) Stop(ctx context.Context) error {
+	close(b.stopped)
+	return nil
+}
+
+// TestStartStop_Timeout 单个 bean 超时与整体超时；超时后才启动成功的 bean 在后台被停止
+func TestStartStop_Timeout(t *testing.T) {
+	late := &ssLate{release: make(chan struct{}), stopped: make(chan struct{})}
+	c := New()
+	c.WithStartTimeout(20*time.Millisecond, 0)
+	c.RegisterBean(late)
+	err := c.LoadE()
+	if !errors.Is(err, ErrStart) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want start timeout, got %v", err)
+	}
+	select {
+	case <-late.stopped:
+		t.Fatal("want no Stop before the abandoned Start returns")
+	default:
+	}
+	close(late.release)
+	select {
+	case <-late.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("want bean started after timeout to be stopped")
+	}
+
+	c = New()
+	c.WithStartTimeout(0, time.Nanosecond)
+	c.RegisterBean(&ssBean{name: "late"})
+	time.Sleep(time.Millisecond)
+	if err := c.LoadE(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want total start timeout, got %v", err)
+	}
+}
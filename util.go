@@ -28,6 +28,30 @@ func GetBeanName(o any) (name string) {
 	return
 }
 
+// matchType 将按类型查找的参数归一化为匹配目标：值类型取 *T，指针类型取 T 或 *T（结构体），接口类型保持原样。
+// beanType 为 nil 时返回 nil。
+func matchType(beanType any) reflect.Type {
+	t := reflect.TypeOf(beanType)
+	if t == nil {
+		return nil
+	}
+	if t.Kind() != reflect.Pointer {
+		return reflect.PointerTo(t)
+	}
+	if t.Elem().Kind() == reflect.Struct {
+		return t
+	}
+	return t.Elem()
+}
+
+// sameInstance 判断 a、b 是否为同一个 bean 实例。只有指针具有实例身份；值类型（可能含 slice 等不可比较的字段）
+// 一律视为不同实例，不做可能 panic 的 == 比较。
+func sameInstance(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	return va.Kind() == reflect.Pointer && vb.Kind() == reflect.Pointer &&
+		va.Type() == vb.Type() && va.Pointer() == vb.Pointer()
+}
+
 // hasPrefix 判断 prefix 是否以 array 中任一字符串为前缀。
 // 返回 (是否命中, 命中的前缀串)；array 为空时视为无条件命中。
 func hasPrefix(prefix string, array []string) (bool, string) {
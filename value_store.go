@@ -20,11 +20,53 @@ type ValueStore interface {
 }
 
 // UseValueStore 替换配置存储实现。必须在 Load 前调用。
+// 子容器替换的是本地层，未设置的 key 仍回退到父容器。
 func (container *di) UseValueStore(v ValueStore) DI {
+	if container.parent != nil {
+		v = &layeredValueStore{ValueStore: v, parent: container.parent}
+	}
 	container.valueStore = v
 	return container
 }
 
+// layeredValueStore 子容器的配置存储：读写落在本地层，本地未设置的 key 回退到父容器的配置。
+type layeredValueStore struct {
+	ValueStore     // 本地层
+	parent     *di // 父容器（按调用时的配置存储读取，父容器替换存储后依然生效）
+}
+
+func (s *layeredValueStore) Get(key string) any {
+	if val := s.ValueStore.Get(key); val != nil {
+		return val
+	}
+	return s.parent.valueStore.Get(key)
+}
+
+// GetAll 合并父容器与本地层的配置，本地层优先。
+func (s *layeredValueStore) GetAll() map[string]any {
+	merged := map[string]any{}
+	mergeProperties(merged, s.parent.valueStore.GetAll())
+	mergeProperties(merged, s.ValueStore.GetAll())
+	return merged
+}
+
+// mergeProperties 将 src 深度合并到 dst，同名 key 以 src 为准（两侧都是 map 时递归合并）。
+func mergeProperties(dst, src map[string]any) {
+	for key, sv := range src {
+		svm, sIsMap := sv.(map[string]any)
+		if dvm, dIsMap := dst[key].(map[string]any); dIsMap && sIsMap {
+			mergeProperties(dvm, svm)
+			continue
+		}
+		if sIsMap {
+			copied := map[string]any{}
+			mergeProperties(copied, svm)
+			sv = copied
+		}
+		dst[key] = sv
+	}
+}
+
 // Property 返回当前配置存储。
 func (container *di) Property() ValueStore {
 	return container.valueStore